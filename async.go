@@ -0,0 +1,260 @@
+package easylogger
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy tells what happens to an entry when the queue of an asynchronous logger is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop drops the entry, see DroppedEntries
+	OverflowDrop
+	// OverflowSpill writes the entry into a temporary spill file, read back once the queue has
+	// room again, and drops it if the spill file is full, see SpilledEntries
+	OverflowSpill
+)
+
+const (
+	// DefaultQueueSize is the default size of the queue of an asynchronous logger
+	DefaultQueueSize = 1024
+
+	// DefaultMaxSpillSize is the default maximum size of the spill file
+	DefaultMaxSpillSize = 64 * 1024 * 1024
+)
+
+// AsyncConfig configures the asynchronous mode, see EnableAsync
+type AsyncConfig struct {
+	// QueueSize is the maximum number of entries waiting to be written, the default is DefaultQueueSize.
+	QueueSize int
+
+	// Overflow is what happens to the entries logged while the queue is full.
+	Overflow OverflowPolicy
+
+	// SpillDir is the directory of the spill file of OverflowSpill, the default is os.TempDir().
+	SpillDir string
+
+	// MaxSpillSize is the maximum size in bytes of the spill file, the default is DefaultMaxSpillSize.
+	MaxSpillSize int64
+}
+
+// asyncItem is a formatted record waiting to be written by the logger l, or a flush request
+type asyncItem struct {
+	l       *EasyLogger
+	r       *record
+	flushed chan struct{}
+	stopped chan struct{} // a stop request, see stop
+}
+
+// asyncQueue is shared by a logger and its clones
+type asyncQueue struct {
+	AsyncConfig
+	ch      chan asyncItem
+	dropped uint64 // accessed atomically
+	spill   spill
+
+	mu      sync.RWMutex // held for reading while sending to ch, so that stop waits for the senders
+	stopped bool
+
+	abandoned int32 // 1 once Shutdown gave up waiting, the queued entries are then dropped, accessed atomically
+}
+
+// EnableAsync makes the fanout stage queue the formatted entries, which are written to the
+// outputs by a background goroutine, so that logging doesn't wait for the disk. The write
+// errors are then only reported by HealthCheck. Use Flush to wait for the queued entries to
+// be written, e.g. before the program exits. It should be called before the logger is used,
+// the clones share the queue of the logger.
+func (this *EasyLogger) EnableAsync(cfg AsyncConfig) {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.SpillDir == "" {
+		cfg.SpillDir = os.TempDir()
+	}
+	if cfg.MaxSpillSize <= 0 {
+		cfg.MaxSpillSize = DefaultMaxSpillSize
+	}
+	q := &asyncQueue{AsyncConfig: cfg, ch: make(chan asyncItem, cfg.QueueSize)}
+	go q.run()
+	this.async = q
+}
+
+// Flush waits for the entries queued in asynchronous mode to be written, then writes the
+// buffered entries to the log files, see WithBuffer.
+func (this *EasyLogger) Flush() {
+	defer this.flushBuffers()
+	if this.async == nil {
+		return
+	}
+	// the spilled entries are written once the queue is empty, after the flush request
+	for {
+		flushed := make(chan struct{})
+		if !this.async.send(asyncItem{flushed: flushed}) {
+			return
+		}
+		<-flushed
+		if this.async.spill.empty() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// DroppedEntries returns the number of entries dropped because the queue was full, or still
+// queued when the deadline of Shutdown expired.
+func (this *EasyLogger) DroppedEntries() uint64 {
+	if this.async == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&this.async.dropped)
+}
+
+// SpilledEntries returns the number of entries written into the spill file because the queue
+// was full, see OverflowSpill.
+func (this *EasyLogger) SpilledEntries() uint64 {
+	if this.async == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&this.async.spill.count)
+}
+
+// enqueue queues the record r of the logger l, dropping or spilling it if the queue is full.
+// It returns false if q is stopped, r is then to be written synchronously.
+func (q *asyncQueue) enqueue(l *EasyLogger, r *record) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.stopped {
+		return false
+	}
+
+	// the pipeline keeps using r, the writer gets its own copy, without the tracing
+	rc := *r
+	rc.trace = nil
+	it := asyncItem{l: l, r: &rc}
+	switch q.Overflow {
+	case OverflowBlock:
+		q.ch <- it
+		return true
+	case OverflowSpill:
+		ok, err := q.spill.push(q, it)
+		if err != nil {
+			diagf(CodeSpill, "entry not spilled: %v", err)
+		}
+		if !ok {
+			atomic.AddUint64(&q.dropped, 1)
+			countError(CodeQueueOverflow)
+		}
+		return true
+	}
+	select {
+	case q.ch <- it:
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+		countError(CodeQueueOverflow)
+	}
+	return true
+}
+
+// send queues it unless q is stopped, it reports whether it was queued
+func (q *asyncQueue) send(it asyncItem) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.stopped {
+		return false
+	}
+	q.ch <- it
+	return true
+}
+
+func (q *asyncQueue) run() {
+	for {
+		var it asyncItem
+		select {
+		case it = <-q.ch:
+		default:
+			// the queue is empty, time to drain the spill file
+			if q.drainSpill() {
+				continue
+			}
+			it = <-q.ch
+		}
+		if it.stopped != nil {
+			q.drain()
+			for q.drainSpill() {
+			}
+			close(it.stopped)
+			return
+		}
+		q.handle(it)
+	}
+}
+
+// handle writes a queued record, or answers a flush request
+func (q *asyncQueue) handle(it asyncItem) {
+	if it.flushed != nil {
+		close(it.flushed)
+		return
+	}
+	if atomic.LoadInt32(&q.abandoned) == 1 {
+		atomic.AddUint64(&q.dropped, 1)
+		return
+	}
+	it.l.write(it.r)
+}
+
+// drainSpill writes a batch of the spilled entries, see spill.drain, or discards them all once
+// q is abandoned
+func (q *asyncQueue) drainSpill() bool {
+	if atomic.LoadInt32(&q.abandoned) == 1 {
+		q.spill.discard()
+		return false
+	}
+	return q.spill.drain()
+}
+
+// drain handles the items queued until now, once the queue is stopped, the spilled entries
+// being written afterwards
+func (q *asyncQueue) drain() {
+	for {
+		select {
+		case it := <-q.ch:
+			q.handle(it)
+		default:
+			return
+		}
+	}
+}
+
+// stop makes the goroutine writing the queued and spilled records exit once they are written,
+// the records logged afterwards are written synchronously
+func (q *asyncQueue) stop() {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	// no more senders once stopped, the stop request is the last item
+	q.stopped = true
+	q.mu.Unlock()
+
+	stopped := make(chan struct{})
+	q.ch <- asyncItem{stopped: stopped}
+	<-stopped
+}
+
+// abandon makes the goroutine drop the entries still queued or spilled instead of writing
+// them, once Shutdown can't wait any longer
+func (q *asyncQueue) abandon() {
+	atomic.StoreInt32(&q.abandoned, 1)
+}
+
+func (q *asyncQueue) isStopped() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.stopped
+}
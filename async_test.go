@@ -0,0 +1,180 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gateWriter blocks the writes until the gate is closed
+type gateWriter struct {
+	gate chan struct{}
+	buf  bytes.Buffer
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	<-w.gate
+	return w.buf.Write(p)
+}
+
+func TestEasyLogger_EnableAsync(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.EnableAsync(AsyncConfig{QueueSize: 4})
+	c := l.Clone(WithPrefix("clone "))
+
+	for i := 0; i < 100; i++ {
+		l.Info("entry", i)
+	}
+	c.Info("from the clone")
+	l.Flush()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 101, len(lines))
+	assert.True(t, strings.HasSuffix(lines[99], "entry 99"))
+	assert.True(t, strings.HasPrefix(lines[100], "clone "))
+	assert.Equal(t, uint64(0), l.DroppedEntries())
+}
+
+func TestEasyLogger_AsyncDrop(t *testing.T) {
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 2, Overflow: OverflowDrop})
+
+	// one entry blocked in the writer, two in the queue, the others dropped
+	for i := 0; i < 10; i++ {
+		l.Info("entry", i)
+	}
+	close(w.gate)
+	l.Flush()
+
+	written := strings.Count(w.buf.String(), "\n")
+	assert.True(t, written >= 2 && written <= 3)
+	assert.Equal(t, uint64(10-written), l.DroppedEntries())
+}
+
+func TestEasyLogger_AsyncSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 2, Overflow: OverflowSpill, SpillDir: dir})
+	c := l.Clone(WithPrefix("clone "))
+
+	for i := 0; i < 20; i++ {
+		l.Info("entry", i)
+	}
+	c.Info("from the clone")
+	assert.True(t, l.SpilledEntries() >= 17)
+	close(w.gate)
+	l.Flush()
+
+	// nothing lost, in order, and the spill file is gone
+	lines := strings.Split(strings.TrimSpace(w.buf.String()), "\n")
+	assert.Equal(t, 21, len(lines))
+	for i := 0; i < 20; i++ {
+		assert.True(t, strings.HasSuffix(lines[i], "entry "+strconv.Itoa(i)))
+	}
+	assert.True(t, strings.HasPrefix(lines[20], "clone "))
+	assert.Equal(t, uint64(0), l.DroppedEntries())
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(infos))
+
+	// the queue is used again once the spill file is drained
+	before := l.SpilledEntries()
+	l.Info("after")
+	l.Flush()
+	assert.Equal(t, before, l.SpilledEntries())
+}
+
+func TestEasyLogger_AsyncSpillFull(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 1, Overflow: OverflowSpill, SpillDir: dir, MaxSpillSize: 400})
+
+	for i := 0; i < 20; i++ {
+		l.Info("entry", i)
+	}
+	close(w.gate)
+	l.Flush()
+
+	written := uint64(strings.Count(w.buf.String(), "\n"))
+	assert.True(t, l.DroppedEntries() > 0)
+	assert.Equal(t, uint64(20), written+l.DroppedEntries())
+}
+
+func TestEasyLogger_AsyncStopDrainsSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 2, Overflow: OverflowSpill, SpillDir: dir})
+	for i := 0; i < 20; i++ {
+		l.Info("entry", i)
+	}
+	assert.True(t, l.SpilledEntries() > 0)
+	close(w.gate)
+	l.async.stop()
+
+	assert.Equal(t, 20, strings.Count(w.buf.String(), "\n"))
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(infos))
+}
+
+func TestEasyLogger_AsyncShutdownDeadline(t *testing.T) {
+	// a slow writer
+	w := &gateWriter{gate: make(chan struct{})}
+	time.AfterFunc(300*time.Millisecond, func() { close(w.gate) })
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 4})
+	for i := 0; i < 4; i++ {
+		l.Info("blocked", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Shutdown(ctx))
+
+	// the entries still queued at the deadline are dropped instead of written
+	assert.Eventually(t, func() bool { return l.DroppedEntries() == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, strings.Count(w.buf.String(), "\n"))
+}
+
+func TestEasyLogger_AsyncCloseWhileLogging(t *testing.T) {
+	for _, overflow := range []OverflowPolicy{OverflowBlock, OverflowDrop} {
+		l := &EasyLogger{out: newSink(ioutil.Discard)}
+		l.EnableAsync(AsyncConfig{QueueSize: 1, Overflow: overflow})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					l.Info("entry", j)
+					if j%50 == 0 {
+						l.Flush()
+					}
+				}
+			}()
+		}
+		assert.Nil(t, l.Close())
+		wg.Wait()
+	}
+}
@@ -0,0 +1,34 @@
+package easylogger
+
+import "time"
+
+// runBatches collects the entries of ch in batches of at most size entries, calling push when
+// a batch is full and at the latest every wait, until ch is closed. push may keep the batch
+// only until it returns.
+func runBatches(ch <-chan Entry, size int, wait time.Duration, push func(batch []Entry)) {
+	ticker := time.NewTicker(wait)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, size)
+	flush := func() {
+		if len(batch) > 0 {
+			push(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= size {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
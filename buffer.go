@@ -0,0 +1,116 @@
+package easylogger
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultFlushInterval is the maximum time an entry stays in the buffer of a log file, see WithBuffer
+const DefaultFlushInterval = time.Second
+
+// bufferedWriter buffers the writes to a log file, flushing them when the buffer is full, when
+// Flush is called, and flushInterval after the first write into an empty buffer. Each write
+// is an entry, the buffer only holds whole entries so that they are never split across files.
+type bufferedWriter struct {
+	flushInterval time.Duration
+	size          int
+
+	mu   sync.Mutex
+	dst  io.Writer
+	buf  []byte
+	ends []int // the end of each entry in buf
+}
+
+// entriesWriter is implemented by the log files writing several entries at once, see
+// Logger.writeEntries
+type entriesWriter interface {
+	writeEntries(p []byte, ends []int) (int, error)
+}
+
+func newBufferedWriter(dst io.Writer, size int, flushInterval time.Duration) *bufferedWriter {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	return &bufferedWriter{flushInterval: flushInterval, size: size, dst: dst, buf: make([]byte, 0, size)}
+}
+
+func (b *bufferedWriter) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.buf)+len(p) > b.size {
+		if err := b.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= b.size {
+		// larger than the buffer, written at once
+		return b.dst.Write(p)
+	}
+	if len(b.buf) == 0 {
+		time.AfterFunc(b.flushInterval, b.flushInBackground)
+	}
+	b.buf = append(b.buf, p...)
+	b.ends = append(b.ends, len(b.buf))
+	return len(p), nil
+}
+
+// Flush writes the buffered entries to the file.
+func (b *bufferedWriter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.flush()
+}
+
+// flush writes the buffered entries, they are lost if the write fails
+func (b *bufferedWriter) flush() error {
+	if len(b.buf) == 0 {
+		return nil
+	}
+	var err error
+	if ew, ok := b.dst.(entriesWriter); ok {
+		_, err = ew.writeEntries(b.buf, b.ends)
+	} else {
+		_, err = b.dst.Write(b.buf)
+	}
+	b.buf, b.ends = b.buf[:0], b.ends[:0]
+	return err
+}
+
+func (b *bufferedWriter) flushInBackground() {
+	if err := b.Flush(); err != nil {
+		diagf(CodeWrite, "buffered entries not written: %v", err)
+	}
+}
+
+// WithBuffer buffers the writes to the log files in memory, up to size bytes, to cut the
+// system calls at high rates. The buffers are written when they are full, by Flush, after
+// the ERROR, FATAL and PANIC entries, and flushInterval after the first entry buffered,
+// DefaultFlushInterval if it is zero, so the last entries can be lost if the program crashes.
+// A size of zero means no buffer.
+func WithBuffer(size int, flushInterval time.Duration) Option {
+	return withSetup(func(s *outputSetup) {
+		s.bufferSize, s.flushInterval = size, flushInterval
+	})
+}
+
+// buffered returns w buffered according to the setup, and adds its buffer to the buffers of the logger
+func (this *EasyLogger) buffered(w io.Writer, s *outputSetup) io.Writer {
+	if s.bufferSize <= 0 {
+		return w
+	}
+	b := newBufferedWriter(w, s.bufferSize, s.flushInterval)
+	this.buffers = append(this.buffers, b)
+	return b
+}
+
+// flushBuffers writes the buffered entries to the log files, it returns the first error
+func (this *EasyLogger) flushBuffers() error {
+	var err error
+	for _, b := range this.buffers {
+		if errFlush := b.Flush(); err == nil {
+			err = errFlush
+		}
+	}
+	return err
+}
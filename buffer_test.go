@@ -0,0 +1,78 @@
+package easylogger
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "app.log")
+	read := func() string {
+		b, _ := ioutil.ReadFile(name)
+		return string(b)
+	}
+
+	l := NewEasyLogger(WithFile(name), WithBuffer(4096, 50*time.Millisecond))
+	l.Info("buffered")
+	assert.Equal(t, "", read())
+
+	// the errors are written at once, with the entries buffered before them
+	l.Error("failed")
+	assert.Contains(t, read(), "buffered")
+	assert.Contains(t, read(), "failed")
+
+	l.Info("flushed by the timer")
+	assert.NotContains(t, read(), "flushed by the timer")
+	time.Sleep(200 * time.Millisecond)
+	assert.Contains(t, read(), "flushed by the timer")
+
+	l.Info("flushed")
+	l.Flush()
+	assert.Equal(t, 4, strings.Count(read(), "\n"))
+	assert.Nil(t, l.Shutdown(context.Background()))
+}
+
+func TestWithBuffer_WholeEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer func() { writeFile = (*os.File).Write }()
+	writes := 0
+	writeFile = func(f *os.File, p []byte) (int, error) {
+		writes++
+		return f.Write(p)
+	}
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxLinesPerFile: 2}
+	b := newBufferedWriter(l, 4096, time.Hour)
+	for i := 0; i < 5; i++ {
+		_, err = b.Write([]byte("line " + strconv.Itoa(i) + "\n"))
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 0, writes)
+	assert.Nil(t, b.Flush())
+	assert.Nil(t, l.Close())
+
+	// the files get whole entries, one write per file
+	assert.Equal(t, 3, writes)
+	name := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat))
+	for i, want := range []string{"line 0\nline 1\n", "line 2\nline 3\n", "line 4\n"} {
+		suffix := FileNameExt
+		if i > 0 {
+			suffix = "." + strconv.Itoa(i) + FileNameExt
+		}
+		b, err := ioutil.ReadFile(name + suffix)
+		assert.Nil(t, err)
+		assert.Equal(t, want, string(b))
+	}
+}
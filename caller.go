@@ -0,0 +1,71 @@
+package easylogger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// EnableCaller adds the caller of the logging calls to the entries of all the levels: its
+// file:line and its function, rendered before the message in text outputs and as the "caller"
+// and "func" keys in JSON outputs, e.g.
+//
+//	[INFO ] GID 1, server.go:42 main.(*Server).handle() request served
+//
+// It should be called before the logger is used.
+func (this *EasyLogger) EnableCaller(enabled bool) {
+	this.callerOn = enabled
+}
+
+// SetCallerSkip sets the number of extra stack frames skipped to find the caller, e.g. 1 for
+// the entries logged through a helper function of the application, so that the caller is the
+// caller of the helper. It should be called before the logger is used, the helpers typically
+// use a clone:
+//
+//	var helperLogger = l.Clone(easylogger.WithCallerSkip(1))
+func (this *EasyLogger) SetCallerSkip(skip int) {
+	if skip < 0 {
+		this.misuse("negative caller skip %d", skip)
+		skip = 0
+	}
+	this.callerSkip = skip
+}
+
+// WithCaller adds the caller to the entries of all the levels, see EnableCaller.
+func WithCaller(enabled bool) Option {
+	return func(l *EasyLogger) {
+		l.EnableCaller(enabled)
+	}
+}
+
+// WithCallerSkip sets the number of extra stack frames skipped to find the caller, see SetCallerSkip.
+func WithCallerSkip(skip int) Option {
+	return func(l *EasyLogger) {
+		l.SetCallerSkip(skip)
+	}
+}
+
+// callSite returns the frame of the caller of the level method calling it, skipping callerSkip
+// more frames
+func (this *EasyLogger) callSite() runtime.Frame {
+	pcs := [1]uintptr{}
+	// 0 is runtime.Callers, 1 callSite, 2 the level method
+	runtime.Callers(3+this.callerSkip, pcs[:])
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	return frame
+}
+
+// callerLocation returns the file:line of frame
+func callerLocation(frame runtime.Frame) string {
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
+
+// shortFunction returns the name of the function without the path of its package, e.g.
+// "main.(*Server).handle"
+func shortFunction(name string) string {
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
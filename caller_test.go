@@ -0,0 +1,52 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// logThroughHelper is a helper of the application wrapping the logger
+func logThroughHelper(l *EasyLogger, msg string) {
+	l.Warn(msg)
+}
+
+func TestEasyLogger_EnableCaller(t *testing.T) {
+	var text, js bytes.Buffer
+	l := &EasyLogger{out: newSink(&text), jsonOut: &js}
+	l.EnableCaller(true)
+
+	l.Info("served") // line 21
+	assert.Contains(t, text.String(), "caller_test.go:21 v2.TestEasyLogger_EnableCaller() served")
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(js.Bytes(), &m))
+	assert.Equal(t, "caller_test.go:21", m["caller"])
+	assert.Equal(t, "v2.TestEasyLogger_EnableCaller", m["func"])
+
+	// the caller of the helper
+	text.Reset()
+	logThroughHelper(l.Clone(WithCallerSkip(1)), "wrapped") // line 30
+	assert.Contains(t, text.String(), "caller_test.go:30 v2.TestEasyLogger_EnableCaller() wrapped")
+	text.Reset()
+	logThroughHelper(l, "unwrapped")
+	assert.Contains(t, text.String(), "caller_test.go:13 v2.logThroughHelper() unwrapped")
+
+	// no duplicated caller for TRACE and DEBUG
+	text.Reset()
+	l.SetLevel(TraceLevel)
+	l.Debugf("%d", 42) // line 39
+	assert.Equal(t, 1, strings.Count(text.String(), "caller_test.go:39"))
+
+	// without EnableCaller, only TRACE and DEBUG have their caller in the message
+	text.Reset()
+	l = &EasyLogger{out: newSink(&text)}
+	l.Debug("debug") // line 45
+	l.Info("info")
+	assert.Contains(t, text.String(), "EasyLogger/v2.TestEasyLogger_EnableCaller caller_test.go:45 debug")
+	assert.Contains(t, text.String(), ", info\n")
+
+	l.SetStrict(true)
+	assert.Panics(t, func() { l.SetCallerSkip(-1) })
+}
@@ -0,0 +1,181 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// captureMemoryLimit is the size above which a capture moves its entries to a temporary file
+var captureMemoryLimit = 4 << 20
+
+// noCapture is the minimum level of the captures when none is active
+const noCapture = int32(PanicLevel + 1)
+
+// captures holds the active captures of a logger and its clones, see CaptureWindow
+type captures struct {
+	mu     sync.RWMutex
+	active []*capture
+	min    int32 // lowest minimum level of the active captures, accessed atomically
+}
+
+// capture records the entries of a CaptureWindow in memory, then in a temporary file once
+// they exceed captureMemoryLimit
+type capture struct {
+	minLevel Level
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	f        *os.File
+	err      error
+}
+
+// CaptureWindow records the entries of the logger and its clones during d, or until ctx is
+// done, then returns them as their text or JSON lines, e.g. to attach them to a bug report.
+// The entries of minLevel and above are recorded even if the level of the logger is higher,
+// without being written to the outputs. The entries are kept in memory, then in a temporary
+// file removed once the returned reader reaches its end or is closed, it is an io.ReadCloser.
+// If ctx is done first, the entries recorded until then are returned with ctx.Err().
+func (this *EasyLogger) CaptureWindow(ctx context.Context, d time.Duration, minLevel Level) (io.Reader, error) {
+	if this.captures == nil {
+		this.captures = &captures{min: noCapture}
+	}
+	c := &capture{minLevel: minLevel}
+	this.captures.add(c)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	var err error
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	this.captures.remove(c)
+
+	r, errReader := c.reader()
+	if errReader != nil {
+		return nil, errReader
+	}
+	return r, err
+}
+
+// wants reports whether an active capture records the entries of level
+func (cs *captures) wants(level Level) bool {
+	return cs != nil && int32(level) >= atomic.LoadInt32(&cs.min)
+}
+
+func (cs *captures) add(c *capture) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.active = append(cs.active, c)
+	cs.updateMin()
+}
+
+func (cs *captures) remove(c *capture) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for i, x := range cs.active {
+		if x == c {
+			cs.active = append(cs.active[:i:i], cs.active[i+1:]...)
+			break
+		}
+	}
+	cs.updateMin()
+}
+
+// updateMin sets min according to the active captures, cs.mu must be locked
+func (cs *captures) updateMin() {
+	min := noCapture
+	for _, c := range cs.active {
+		if int32(c.minLevel) < min {
+			min = int32(c.minLevel)
+		}
+	}
+	atomic.StoreInt32(&cs.min, min)
+}
+
+// write records r in the captures wanting its level
+func (cs *captures) write(r *record) {
+	if !cs.wants(r.Level) {
+		return
+	}
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	for _, c := range cs.active {
+		if r.Level >= c.minLevel {
+			c.write(r.line())
+		}
+	}
+}
+
+func (c *capture) write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	if c.f == nil && c.buf.Len()+len(p) > captureMemoryLimit {
+		if c.f, c.err = ioutil.TempFile("", "easylogger-capture-"); c.err != nil {
+			diagf(CodeCapture, "capture not written to a temporary file: %v", c.err)
+			return
+		}
+		_, c.err = c.f.Write(c.buf.Bytes())
+		c.buf = bytes.Buffer{}
+	}
+	if c.f != nil {
+		if c.err == nil {
+			_, c.err = c.f.Write(p)
+		}
+		return
+	}
+	c.buf.Write(p)
+}
+
+// reader returns the recorded entries
+func (c *capture) reader() (io.Reader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return ioutil.NopCloser(bytes.NewReader(c.buf.Bytes())), nil
+	}
+	if c.err == nil {
+		_, c.err = c.f.Seek(0, io.SeekStart)
+	}
+	if c.err != nil {
+		c.f.Close()
+		os.Remove(c.f.Name())
+		return nil, coded(CodeCapture, c.err)
+	}
+	return &captureFile{f: c.f}, nil
+}
+
+// captureFile reads the temporary file of a capture, removing it at the end
+type captureFile struct {
+	f      *os.File
+	closed bool
+}
+
+func (cf *captureFile) Read(p []byte) (int, error) {
+	if cf.closed {
+		return 0, io.EOF
+	}
+	n, err := cf.f.Read(p)
+	if err == io.EOF {
+		cf.Close()
+	}
+	return n, err
+}
+
+// Close removes the temporary file.
+func (cf *captureFile) Close() error {
+	if cf.closed {
+		return nil
+	}
+	cf.closed = true
+	cf.f.Close()
+	return os.Remove(cf.f.Name())
+}
@@ -0,0 +1,76 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_CaptureWindow(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), captures: &captures{min: noCapture}}
+	l.SetLevel(WarnLevel)
+	assert.False(t, l.Enabled(DebugLevel))
+
+	started := make(chan struct{})
+	logged := make(chan struct{})
+	go func() {
+		<-started
+		for !l.Enabled(DebugLevel) {
+			time.Sleep(time.Millisecond)
+		}
+		l.Trace("not captured")
+		l.Debug("captured debug")
+		l.Clone().Warn("captured warn")
+		close(logged)
+	}()
+	close(started)
+	r, err := l.CaptureWindow(context.Background(), 200*time.Millisecond, DebugLevel)
+	<-logged
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "captured debug\n")
+	assert.Contains(t, string(b), "captured warn\n")
+	assert.NotContains(t, string(b), "not captured")
+
+	// the outputs only get the entries of their level
+	assert.NotContains(t, buf.String(), "captured debug")
+	assert.Contains(t, buf.String(), "captured warn")
+	assert.False(t, l.Enabled(DebugLevel))
+	l.Debug("after")
+	assert.NotContains(t, buf.String(), "after")
+}
+
+func TestEasyLogger_CaptureWindowTempFile(t *testing.T) {
+	defer func(limit int) { captureMemoryLimit = limit }(captureMemoryLimit)
+	captureMemoryLimit = 10
+
+	l := &EasyLogger{out: newSink(newMultiWriter()), captures: &captures{min: noCapture}}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for !l.Enabled(InfoLevel) {
+			time.Sleep(time.Millisecond)
+		}
+		l.Info("first entry")
+		l.Info("second entry")
+		cancel()
+	}()
+	r, err := l.CaptureWindow(ctx, time.Hour, InfoLevel)
+	assert.Equal(t, context.Canceled, err)
+	cf, ok := r.(*captureFile)
+	assert.True(t, ok)
+	name := cf.f.Name()
+	b, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "first entry\n")
+	assert.Contains(t, string(b), "second entry\n")
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, r.(io.Closer).Close())
+}
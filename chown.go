@@ -1,6 +1,6 @@
-// +build !linux
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
 
-package EasyLogger
+package easylogger
 
 import (
 	"os"
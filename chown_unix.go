@@ -0,0 +1,20 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package easylogger
+
+import (
+	"os"
+	"syscall"
+)
+
+// osChown exists so it can be mocked out by tests
+var osChown = os.Chown
+
+// chown gives the file name the owner and the group of the file of info
+func chown(name string, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	return osChown(name, int(stat.Uid), int(stat.Gid))
+}
@@ -0,0 +1,48 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package easylogger
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogger_PreservesOwner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	chowned := make(map[string][2]int)
+	osChown = func(name string, uid, gid int) error {
+		chowned[filepath.Base(name)] = [2]int{uid, gid}
+		return nil
+	}
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	megabyte = 1
+	defer func() {
+		osChown = os.Chown
+		currentTime = time.Now
+		megabyte = 1024 * 1024
+	}()
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10, Compress: true}
+	for _, s := range []string{"aaaa\n", "bbbbbbbb\n"} {
+		_, err := l.Write([]byte(s))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, l.Shutdown(context.Background()))
+
+	// the first file has no previous file, the next one and the compressed files keep its owner
+	owner := [2]int{os.Getuid(), os.Getgid()}
+	assert.Equal(t, map[string][2]int{
+		"2021-09-01.1.log":    owner,
+		"2021-09-01.log.gz":   owner,
+		"2021-09-01.1.log.gz": owner,
+	}, chowned)
+}
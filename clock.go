@@ -0,0 +1,84 @@
+package easylogger
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// ClockSkewField is the field set to true on the entries logged when a clock jump is detected
+	ClockSkewField = "clock_skew"
+
+	// DefaultClockTolerance is the drift between the wall clock and the monotonic clock
+	// considered as a clock jump
+	DefaultClockTolerance = time.Second
+)
+
+// hybridClock derives the entry times from the monotonic clock, anchored on the wall clock.
+// When the wall clock drifts away from it by more than tolerance, e.g. when a VM is resumed
+// or the clock is set, it is anchored on the wall clock again and the jump is reported.
+type hybridClock struct {
+	tolerance time.Duration
+
+	mu     sync.Mutex
+	anchor time.Time // wall time of the last anchoring, without monotonic reading
+	mono   time.Time // time of the last anchoring, for its monotonic reading
+}
+
+func newHybridClock(tolerance time.Duration) *hybridClock {
+	if tolerance <= 0 {
+		tolerance = DefaultClockTolerance
+	}
+	n := time.Now()
+	return &hybridClock{tolerance: tolerance, anchor: n.Round(0), mono: n}
+}
+
+// now returns the time of an entry, and whether a clock jump has been detected
+func (c *hybridClock) now() (time.Time, bool) {
+	n := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.anchor.Add(n.Sub(c.mono))
+	wall := n.Round(0)
+	if drift := wall.Sub(t); drift > c.tolerance || drift < -c.tolerance {
+		c.anchor, c.mono = wall, n
+		return wall, true
+	}
+	return t, false
+}
+
+// SetHybridClock makes the logger take the entry times from a hybrid clock, the wall clock
+// corrected by the monotonic clock, so that they stay consistent when the wall clock is
+// adjusted. A drift between both clocks greater than tolerance is a clock jump: the entry
+// logged when it is detected gets a "clock_skew" field set to true, and the following ones
+// follow the wall clock again. A tolerance of zero means DefaultClockTolerance.
+// It should be called before the logger is used.
+func (this *EasyLogger) SetHybridClock(tolerance time.Duration) {
+	this.clock = newHybridClock(tolerance)
+}
+
+// WithHybridClock sets a hybrid clock, see SetHybridClock.
+func WithHybridClock(tolerance time.Duration) Option {
+	return func(l *EasyLogger) {
+		l.SetHybridClock(tolerance)
+	}
+}
+
+// now returns the time of an entry and its fields, with ClockSkewField on a clock jump
+func (this *EasyLogger) now() (time.Time, Fields) {
+	if this.clock == nil {
+		return time.Now(), this.fields
+	}
+	t, skew := this.clock.now()
+	if !skew {
+		return t, this.fields
+	}
+	fields := make(Fields, len(this.fields)+1)
+	for k, v := range this.fields {
+		fields[k] = v
+	}
+	fields[ClockSkewField] = true
+	return t, fields
+}
@@ -0,0 +1,46 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHybridClock(t *testing.T) {
+	c := newHybridClock(0)
+	assert.Equal(t, DefaultClockTolerance, c.tolerance)
+
+	t1, skew := c.now()
+	assert.False(t, skew)
+	t2, skew := c.now()
+	assert.False(t, skew)
+	assert.False(t, t2.Before(t1))
+
+	// the wall clock jumped forward while the monotonic clock did not, e.g. a VM resumed
+	c.anchor = c.anchor.Add(-time.Minute)
+	t3, skew := c.now()
+	assert.True(t, skew)
+	assert.WithinDuration(t, time.Now(), t3, time.Second)
+	_, skew = c.now()
+	assert.False(t, skew)
+}
+
+func TestEasyLogger_HybridClock(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l = l.Clone(WithHybridClock(time.Second), withFields(Fields{"app": "test"}))
+
+	l.Info("steady")
+	l.clock.anchor = l.clock.anchor.Add(time.Minute)
+	l.Info("jump")
+	l.Info("steady again")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.NotContains(t, lines[0], ClockSkewField)
+	assert.Contains(t, lines[1], "app=test clock_skew=true")
+	assert.NotContains(t, lines[2], ClockSkewField)
+	assert.Equal(t, 1, len(l.fields))
+}
@@ -0,0 +1,17 @@
+// +build !nocolor
+
+package easylogger
+
+import (
+	"github.com/gookit/color"
+)
+
+var (
+	Trace LevelColor = color.Cyan
+	Debug LevelColor = color.Blue
+	Info  LevelColor = color.Green
+	Warn  LevelColor = color.Yellow
+	Error LevelColor = color.Red
+	Fatal LevelColor = color.Magenta
+	Panic LevelColor = color.LightRed
+)
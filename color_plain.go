@@ -0,0 +1,13 @@
+// +build nocolor
+
+package easylogger
+
+var (
+	Trace LevelColor = PlainColor{}
+	Debug LevelColor = PlainColor{}
+	Info  LevelColor = PlainColor{}
+	Warn  LevelColor = PlainColor{}
+	Error LevelColor = PlainColor{}
+	Fatal LevelColor = PlainColor{}
+	Panic LevelColor = PlainColor{}
+)
@@ -0,0 +1,71 @@
+package easylogger
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// ColorMode tells which outputs get colored level tags
+type ColorMode int32
+
+const (
+	// ColorAlways colors the level tags in all the outputs, the default
+	ColorAlways ColorMode = iota
+	// ColorConsole colors the level tags on the console only, and only if it is a terminal,
+	// the log files get plain text
+	ColorConsole
+	// ColorNever renders plain level tags everywhere
+	ColorNever
+)
+
+// noColor is set when the NO_COLOR environment variable is, see https://no-color.org,
+// the level tags are then never colored
+var noColor = os.Getenv("NO_COLOR") != ""
+
+// LevelColor renders the level tags, the default ones are set in color_gookit.go, or in
+// color_plain.go when building with the nocolor tag, which drops the gookit/color dependency.
+type LevelColor interface {
+	Sprint(a ...interface{}) string
+}
+
+// PlainColor renders the level tags as plain text
+type PlainColor struct{}
+
+func (PlainColor) Sprint(a ...interface{}) string {
+	return fmt.Sprint(a...)
+}
+
+// SetColorMode sets which outputs get colored level tags, it is safe to call at any time.
+func (this *EasyLogger) SetColorMode(m ColorMode) {
+	atomic.StoreInt32(&this.colorMode, int32(m))
+}
+
+// WithColorMode sets which outputs get colored level tags.
+func WithColorMode(m ColorMode) Option {
+	return func(l *EasyLogger) {
+		l.SetColorMode(m)
+	}
+}
+
+// colorOut reports whether the level tags are colored in the outputs other than the console
+func (this *EasyLogger) colorOut() bool {
+	return !noColor && ColorMode(atomic.LoadInt32(&this.colorMode)) == ColorAlways
+}
+
+// colorConsole reports whether the level tags are colored on the console
+func (this *EasyLogger) colorConsole() bool {
+	switch ColorMode(atomic.LoadInt32(&this.colorMode)) {
+	case ColorAlways:
+		return !noColor
+	case ColorConsole:
+		return !noColor && this.consoleTTY
+	}
+	return false
+}
+
+// isTerminal reports whether f is a terminal rather than a file or a pipe
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
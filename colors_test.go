@@ -0,0 +1,47 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPlainColor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+
+	old := Info
+	Info = PlainColor{}
+	defer func() { Info = old }()
+
+	l.Info("hello world")
+	assert.Equal(t, "[INFO ] GID ", buf.String()[:12])
+}
+
+func TestEasyLogger_SetColorMode(t *testing.T) {
+	var out, console bytes.Buffer
+	l := &EasyLogger{out: newSink(&out), console: newSink(&console)}
+	colored, plain := Info.Sprint(INFO)+" GID", INFO+" GID"
+	check := func(wantOut, wantConsole string) {
+		t.Helper()
+		out.Reset()
+		console.Reset()
+		l.Info("hello")
+		assert.True(t, strings.HasPrefix(out.String(), wantOut), out.String())
+		assert.True(t, strings.HasPrefix(console.String(), wantConsole), console.String())
+	}
+
+	check(colored, colored)
+	l.SetColorMode(ColorConsole)
+	check(plain, plain) // the console is not a terminal
+	l.consoleTTY = true
+	check(plain, colored)
+	l.SetColorMode(ColorNever)
+	check(plain, plain)
+
+	l.SetColorMode(ColorAlways)
+	noColor = true
+	defer func() { noColor = false }()
+	check(plain, plain)
+}
@@ -0,0 +1,163 @@
+package easylogger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxCommandOutput is the maximum number of output bytes recorded by LogCommand
+	MaxCommandOutput = 4096
+
+	redacted = "<redacted>"
+)
+
+// sensitiveEnvKeys are the parts of environment variable names whose values are never logged
+var sensitiveEnvKeys = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "KEY", "CREDENTIAL", "AUTH"}
+
+// LogCommand runs cmd and logs one entry describing its execution: the command line, the
+// environment variables it changed (sensitive values redacted), the duration, the exit code
+// and the first MaxCommandOutput bytes of its combined output. The entry is logged at INFO
+// level if the command succeeds, ERROR otherwise. The process is killed if ctx is done
+// before it exits. It returns the error of running cmd.
+func (this *EasyLogger) LogCommand(ctx context.Context, cmd *exec.Cmd) error {
+	out := &truncatingBuffer{max: MaxCommandOutput}
+	if cmd.Stdout == nil {
+		cmd.Stdout = out
+	} else {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, out)
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = out
+	} else {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, out)
+	}
+
+	start := time.Now()
+	err := runContext(ctx, cmd)
+	duration := time.Since(start)
+
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+
+	msg := fmt.Sprintf("command=%s env=%s duration=%s exit_code=%d output=%s",
+		strconv.Quote(strings.Join(cmd.Args, " ")),
+		strconv.Quote(strings.Join(envDiff(os.Environ(), cmd.Env), " ")),
+		duration, exitCode, strconv.Quote(out.String()))
+	if err != nil {
+		this.output(ErrorLevel, msg, "error="+strconv.Quote(err.Error()))
+	} else {
+		this.output(InfoLevel, msg)
+	}
+	return err
+}
+
+// runContext runs cmd, killing its process if ctx is done before it exits
+func runContext(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		cmd.Process.Kill()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// envDiff lists the variables env adds, changes or removes compared to base.
+// A nil env means the command inherits base, so there is no difference.
+func envDiff(base []string, env []string) []string {
+	if env == nil {
+		return nil
+	}
+	baseMap := envMap(base)
+	envM := envMap(env)
+
+	var diff []string
+	for k, v := range envM {
+		if old, ok := baseMap[k]; !ok || old != v {
+			if isSensitiveEnv(k) {
+				v = redacted
+			}
+			diff = append(diff, k+"="+v)
+		}
+	}
+	for k := range baseMap {
+		if _, ok := envM[k]; !ok {
+			diff = append(diff, "-"+k)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func envMap(env []string) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, kv := range env {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			m[kv] = ""
+			continue
+		}
+		m[kv[:i]] = kv[i+1:]
+	}
+	return m
+}
+
+func isSensitiveEnv(key string) bool {
+	key = strings.ToUpper(key)
+	for _, s := range sensitiveEnvKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncatingBuffer keeps the first max bytes written to it and discards the rest
+type truncatingBuffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func (b *truncatingBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if room := b.max - len(b.buf); room < len(p) {
+		b.buf = append(b.buf, p[:room]...)
+		b.truncated = true
+	} else {
+		b.buf = append(b.buf, p...)
+	}
+	return len(p), nil
+}
+
+func (b *truncatingBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return string(b.buf) + "...(truncated)"
+	}
+	return string(b.buf)
+}
@@ -0,0 +1,56 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_LogCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a unix shell")
+	}
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+
+	cmd := exec.Command("sh", "-c", "echo hello; exit 3")
+	cmd.Env = []string{"API_TOKEN=s3cr3t", "GREETING=hi"}
+	err := l.LogCommand(context.Background(), cmd)
+	assert.NotNil(t, err)
+
+	line := buf.String()
+	assert.Contains(t, line, ERROR)
+	assert.Contains(t, line, `command="sh -c echo hello; exit 3"`)
+	assert.Contains(t, line, "API_TOKEN="+redacted)
+	assert.Contains(t, line, "GREETING=hi")
+	assert.NotContains(t, line, "s3cr3t")
+	assert.Contains(t, line, "exit_code=3")
+	assert.Contains(t, line, `output="hello\n"`)
+
+	buf.Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = l.LogCommand(ctx, exec.Command("sleep", "10"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Contains(t, buf.String(), ERROR)
+}
+
+func TestTruncatingBuffer(t *testing.T) {
+	b := &truncatingBuffer{max: 5}
+	n, err := b.Write([]byte("hello world"))
+	assert.Nil(t, err)
+	assert.Equal(t, 11, n)
+	assert.True(t, strings.HasPrefix(b.String(), "hello..."))
+}
+
+func TestEnvDiff(t *testing.T) {
+	base := []string{"A=1", "B=2", "SECRET_KEY=x"}
+	assert.Nil(t, envDiff(base, nil))
+	assert.Equal(t, []string{"-A", "B=3", "C=4", "SECRET_KEY=" + redacted},
+		envDiff(base, []string{"B=3", "C=4", "SECRET_KEY=y"}))
+}
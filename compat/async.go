@@ -0,0 +1,20 @@
+// Code generated by gen.go from async.go; DO NOT EDIT.
+
+package EasyLogger
+
+import (
+	easylogger "github.com/joeqian10/EasyLogger/v2"
+)
+
+type (
+	OverflowPolicy = easylogger.OverflowPolicy
+	AsyncConfig    = easylogger.AsyncConfig
+)
+
+const (
+	OverflowBlock       = easylogger.OverflowBlock
+	OverflowDrop        = easylogger.OverflowDrop
+	OverflowSpill       = easylogger.OverflowSpill
+	DefaultQueueSize    = easylogger.DefaultQueueSize
+	DefaultMaxSpillSize = easylogger.DefaultMaxSpillSize
+)
@@ -0,0 +1,19 @@
+package EasyLogger
+
+import (
+	"github.com/joeqian10/EasyLogger/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompat(t *testing.T) {
+	// the same types as easylogger
+	var level easylogger.Level = WarnLevel
+	assert.Equal(t, easylogger.WarnLevel, level)
+
+	var fields easylogger.Fields = Fields{"user": 42}
+	assert.Equal(t, "user=42", fields.String())
+
+	var opt easylogger.Option = WithLevel(ErrorLevel)
+	assert.NotNil(t, opt)
+}
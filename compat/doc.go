@@ -0,0 +1,14 @@
+// Package EasyLogger keeps the code written for github.com/joeqian10/EasyLogger building with
+// the v2 module: it re-exports the API of easylogger under the former package name, so that
+// moving to v2 only changes the import path, e.g.
+//
+//	import "github.com/joeqian10/EasyLogger/v2/compat"
+//
+//	l := EasyLogger.NewEasyLogger(EasyLogger.WithDirectory("./Logs/"))
+//
+// The types are aliases of those of easylogger, so both packages can be used together. The
+// variables are copies taken at init, the ones which can be set, e.g. DefaultRetryPolicy, are
+// to be set in easylogger.
+package EasyLogger
+
+//go:generate go run gen.go
@@ -0,0 +1,230 @@
+// +build ignore
+
+// gen generates the compat package from the exported declarations of easylogger: an alias of
+// each type, a copy of each constant and variable, and a wrapper of each function, in a file
+// named and constrained like the source file. Run it with go generate from this directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const modulePath = "github.com/joeqian10/EasyLogger/v2"
+
+// generatedHeader matches the header of the generated files
+var generatedHeader = regexp.MustCompile(`(?m)^// Code generated by gen\.go from \S+; DO NOT EDIT\.$`)
+
+func main() {
+	sources, err := filepath.Glob("../*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	old, err := filepath.Glob("*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range old {
+		if b, err := ioutil.ReadFile(name); err == nil && generatedHeader.Match(b) {
+			os.Remove(name)
+		}
+	}
+
+	fset := token.NewFileSet()
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b, err := generate(fset, f, filepath.Base(source))
+		if err != nil {
+			log.Fatal(source, ": ", err)
+		}
+		if b != nil {
+			if err = ioutil.WriteFile(filepath.Base(source), b, 0644); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+}
+
+// generate returns the compat file of the source file f, nil if it exports nothing
+func generate(fset *token.FileSet, f *ast.File, name string) ([]byte, error) {
+	var types, consts, vars, funcs bytes.Buffer
+	used := map[string]bool{}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						fmt.Fprintf(&types, "\t%s = easylogger.%[1]s\n", spec.Name.Name)
+					}
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						if !n.IsExported() {
+							continue
+						}
+						if d.Tok == token.CONST {
+							fmt.Fprintf(&consts, "\t%s = easylogger.%[1]s\n", n.Name)
+						} else {
+							fmt.Fprintf(&vars, "\t%s = easylogger.%[1]s\n", n.Name)
+						}
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv != nil || !d.Name.IsExported() {
+				continue
+			}
+			if err := wrap(&funcs, fset, d, used); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if types.Len()+consts.Len()+vars.Len()+funcs.Len() == 0 {
+		return nil, nil
+	}
+
+	var b bytes.Buffer
+	for _, c := range f.Comments {
+		if c.Pos() > f.Package {
+			break
+		}
+		for _, l := range c.List {
+			if strings.HasPrefix(l.Text, "// +build ") {
+				b.WriteString(l.Text + "\n")
+			}
+		}
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "// Code generated by gen.go from %s; DO NOT EDIT.\n\npackage EasyLogger\n\nimport (\n", name)
+	var imports []string
+	for _, spec := range f.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		local := importName(p)
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+		if used[local] {
+			imports = append(imports, fmt.Sprintf("\t%s %q\n", local, p))
+		}
+	}
+	imports = append(imports, fmt.Sprintf("\teasylogger %q\n", modulePath))
+	sort.Strings(imports)
+	for _, i := range imports {
+		b.WriteString(i)
+	}
+	b.WriteString(")\n")
+	for _, block := range []struct {
+		tok string
+		buf bytes.Buffer
+	}{{"type", types}, {"const", consts}, {"var", vars}} {
+		if block.buf.Len() > 0 {
+			fmt.Fprintf(&b, "\n%s (\n%s)\n", block.tok, block.buf.String())
+		}
+	}
+	b.Write(funcs.Bytes())
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	// the build constraints are written like in easylogger, without the //go:build line
+	if bytes.HasPrefix(src, []byte("//go:build ")) {
+		src = src[bytes.IndexByte(src, '\n')+1:]
+	}
+	return src, nil
+}
+
+// wrap writes a function calling the function d of easylogger, with the same signature
+func wrap(w *bytes.Buffer, fset *token.FileSet, d *ast.FuncDecl, used map[string]bool) error {
+	ft := *d.Type
+	var args []string
+	if ft.Params != nil {
+		params := *ft.Params
+		params.List = nil
+		for i, field := range ft.Params.List {
+			field := *field
+			if len(field.Names) == 0 {
+				field.Names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("a%d", i))}
+			}
+			names := make([]*ast.Ident, len(field.Names))
+			for j, n := range field.Names {
+				if n.Name == "_" {
+					n = ast.NewIdent(fmt.Sprintf("a%d_%d", i, j))
+				}
+				names[j] = n
+				arg := n.Name
+				if _, ok := field.Type.(*ast.Ellipsis); ok {
+					arg += "..."
+				}
+				args = append(args, arg)
+			}
+			field.Names = names
+			params.List = append(params.List, &field)
+		}
+		ft.Params = &params
+	}
+
+	var unexported error
+	ast.Inspect(&ft, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				used[x.Name] = true
+			}
+			return false
+		case *ast.Ident:
+			if n.Obj != nil && n.Obj.Kind == ast.Typ && !n.IsExported() {
+				unexported = fmt.Errorf("%s uses the unexported type %s", d.Name.Name, n.Name)
+			}
+		}
+		return true
+	})
+	if unexported != nil {
+		return unexported
+	}
+
+	fmt.Fprintf(w, "\n// %s calls easylogger.%[1]s.\n", d.Name.Name)
+	if err := printer.Fprint(w, fset, &ast.FuncDecl{Name: d.Name, Type: &ft}); err != nil {
+		return err
+	}
+	call := fmt.Sprintf("easylogger.%s(%s)", d.Name.Name, strings.Join(args, ", "))
+	if ft.Results != nil && len(ft.Results.List) > 0 {
+		call = "return " + call
+	}
+	fmt.Fprintf(w, " {\n\t%s\n}\n", call)
+	return nil
+}
+
+// importName returns the default name of the package imported with the path p
+func importName(p string) string {
+	name := path.Base(p)
+	if strings.HasPrefix(name, "v") && len(name) > 1 && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
@@ -0,0 +1,27 @@
+// Code generated by gen.go from timeRotating.go; DO NOT EDIT.
+
+package EasyLogger
+
+import (
+	easylogger "github.com/joeqian10/EasyLogger/v2"
+)
+
+type (
+	Logger = easylogger.Logger
+)
+
+const (
+	DefaultLogDir            = easylogger.DefaultLogDir
+	FileNameTimeFormat       = easylogger.FileNameTimeFormat
+	FileNameExt              = easylogger.FileNameExt
+	JSONFileNameExt          = easylogger.JSONFileNameExt
+	ErrorFileNameExt         = easylogger.ErrorFileNameExt
+	CompressSuffix           = easylogger.CompressSuffix
+	HourFileNameTimeFormat   = easylogger.HourFileNameTimeFormat
+	MinuteFileNameTimeFormat = easylogger.MinuteFileNameTimeFormat
+	NanosecondPerDay         = easylogger.NanosecondPerDay
+)
+
+var (
+	ErrLoggerShutdown = easylogger.ErrLoggerShutdown
+)
@@ -0,0 +1,214 @@
+package easylogger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// names of the compressions of the rotated log files, see Logger.Compression
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+	CompressionLZ4  = "lz4"
+	CompressionNone = "none"
+)
+
+// Compressor compresses the rotated log files. gzip is built in, other algorithms such as
+// zstd and lz4 are added with RegisterCompressor to keep their libraries out of the binaries
+// which don't need them.
+type Compressor interface {
+	// Ext is the extension added to the compressed files, e.g. ".gz"
+	Ext() string
+
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var compressors = struct {
+	sync.RWMutex
+	m map[string]Compressor
+}{m: map[string]Compressor{CompressionGzip: gzipCompressor{}}}
+
+// RegisterCompressor makes the Compressor c available as the Compression name, e.g.
+//
+//	easylogger.RegisterCompressor(easylogger.CompressionZstd, zstdCompressor{})
+//
+// It should be called before the loggers are used, typically from an init function.
+func RegisterCompressor(name string, c Compressor) {
+	compressors.Lock()
+	defer compressors.Unlock()
+	compressors.m[name] = c
+}
+
+// compressorByName returns the Compressor registered as name
+func compressorByName(name string) (Compressor, error) {
+	compressors.RLock()
+	defer compressors.RUnlock()
+	if c, ok := compressors.m[name]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("unknown compression %q, see RegisterCompressor", name)
+}
+
+// compressorOf returns the Compressor of the file name according to its extension,
+// nil if the file is not compressed
+func compressorOf(name string) Compressor {
+	compressors.RLock()
+	defer compressors.RUnlock()
+	for _, c := range compressors.m {
+		if strings.HasSuffix(name, c.Ext()) {
+			return c
+		}
+	}
+	return nil
+}
+
+// compressedExt returns the extension of the compression of the file name, "" if the file
+// is not compressed
+func compressedExt(name string) string {
+	if c := compressorOf(name); c != nil {
+		return c.Ext()
+	}
+	return ""
+}
+
+// compressedExists reports whether a compressed copy of the file name exists
+func compressedExists(name string) bool {
+	compressors.RLock()
+	defer compressors.RUnlock()
+	for _, c := range compressors.m {
+		if _, err := osStat(name + c.Ext()); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) Ext() string {
+	return CompressSuffix
+}
+
+func (gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// CompressionLimits bounds the resources used to compress the rotated files of all the Loggers,
+// so that the retention maintenance can't starve the application, see SetCompressionLimits
+type CompressionLimits struct {
+	// Workers is the maximum number of files compressed at the same time, the default is 1.
+	Workers int
+
+	// BytesPerSecond is the maximum rate at which each worker reads the files, the default
+	// is no limit.
+	BytesPerSecond int64
+}
+
+// CompressionStats reports the work of the compression pool
+type CompressionStats struct {
+	Files    uint64        // files compressed
+	Failures uint64        // files which failed to compress
+	Bytes    uint64        // bytes compressed, before compression
+	Duration time.Duration // total time spent compressing, including the waits for a worker
+}
+
+// compressionPool holds the current CompressionLimits and the workers slots
+type compressionPool struct {
+	CompressionLimits
+	slots chan struct{}
+}
+
+var (
+	pool atomic.Value // *compressionPool
+
+	compressedFiles  uint64 // accessed atomically
+	compressFailures uint64 // accessed atomically
+	compressedBytes  uint64 // accessed atomically
+	compressionNanos uint64 // accessed atomically
+)
+
+func init() {
+	SetCompressionLimits(CompressionLimits{})
+}
+
+// SetCompressionLimits sets the limits of the compression of the rotated files of all the
+// Loggers, it is safe to call at any time. The compressions in progress keep the previous limits.
+func SetCompressionLimits(limits CompressionLimits) {
+	if limits.Workers <= 0 {
+		limits.Workers = 1
+	}
+	pool.Store(&compressionPool{CompressionLimits: limits, slots: make(chan struct{}, limits.Workers)})
+}
+
+// GetCompressionStats returns the statistics of the compression of the rotated files.
+func GetCompressionStats() CompressionStats {
+	return CompressionStats{
+		Files:    atomic.LoadUint64(&compressedFiles),
+		Failures: atomic.LoadUint64(&compressFailures),
+		Bytes:    atomic.LoadUint64(&compressedBytes),
+		Duration: time.Duration(atomic.LoadUint64(&compressionNanos)),
+	}
+}
+
+// compressPooled compresses src into dst with c once a worker of the pool is available
+func compressPooled(src, dst string, c Compressor) error {
+	start := time.Now()
+	p := pool.Load().(*compressionPool)
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+
+	var size int64
+	if fi, err := osStat(src); err == nil {
+		size = fi.Size()
+	}
+	err := compressLogFile(src, dst, c)
+	atomic.AddUint64(&compressionNanos, uint64(time.Since(start)))
+	if err != nil {
+		atomic.AddUint64(&compressFailures, 1)
+		return coded(CodeCompression, err)
+	}
+	atomic.AddUint64(&compressedFiles, 1)
+	atomic.AddUint64(&compressedBytes, uint64(size))
+	return nil
+}
+
+// rateLimitedReader reads at most rate bytes per second on average
+type rateLimitedReader struct {
+	r     io.Reader
+	rate  int64
+	start time.Time
+	n     int64
+}
+
+// rateLimited limits the reading of r to the BytesPerSecond of the compression pool
+func rateLimited(r io.Reader) io.Reader {
+	rate := pool.Load().(*compressionPool).BytesPerSecond
+	if rate <= 0 {
+		return r
+	}
+	return &rateLimitedReader{r: r, rate: rate, start: time.Now()}
+}
+
+func (rl *rateLimitedReader) Read(p []byte) (int, error) {
+	// small reads keep the rate smooth
+	if max := rl.rate/10 + 1; int64(len(p)) > max {
+		p = p[:max]
+	}
+	n, err := rl.r.Read(p)
+	rl.n += int64(n)
+	due := time.Duration(float64(rl.n) / float64(rl.rate) * float64(time.Second))
+	if wait := due - time.Since(rl.start); wait > 0 {
+		time.Sleep(wait)
+	}
+	return n, err
+}
@@ -0,0 +1,132 @@
+package easylogger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// copyCompressor "compresses" by copying, to test the registry
+type copyCompressor struct{}
+
+func (copyCompressor) Ext() string { return ".raw" }
+
+func (copyCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (copyCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(r), nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestLogger_Compression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"2021-09-01.log", "2021-09-02.log"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644))
+	}
+
+	assert.Equal(t, "", (&Logger{}).compression())
+	assert.Equal(t, CompressionGzip, (&Logger{Compress: true}).compression())
+	assert.Equal(t, "", (&Logger{Compress: true, Compression: CompressionNone}).compression())
+
+	l := &Logger{Directory: dir, MaxDays: 1, Compression: CompressionZstd}
+	assert.NotNil(t, l.config().millRunOnce())
+
+	RegisterCompressor("raw", copyCompressor{})
+	l.Compression = "raw"
+	assert.Nil(t, l.config().millRunOnce())
+	b, err := ioutil.ReadFile(filepath.Join(dir, "2021-09-01.log.raw"))
+	assert.Nil(t, err)
+	assert.Equal(t, "x\n", string(b))
+
+	files, err := l.oldLogFiles()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, "2021-09-02.log", files[0].Name())
+	assert.True(t, isLogFileName("2021-09-01.log.raw"))
+}
+
+// countingCompressor copies, recording the highest number of files compressed at the same time
+type countingCompressor struct {
+	copyCompressor
+	active, max *int32
+}
+
+func (c countingCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	n := atomic.AddInt32(c.active, 1)
+	for {
+		m := atomic.LoadInt32(c.max)
+		if n <= m || atomic.CompareAndSwapInt32(c.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return countingWriter{nopWriteCloser{w}, c.active}, nil
+}
+
+type countingWriter struct {
+	nopWriteCloser
+	active *int32
+}
+
+func (w countingWriter) Close() error {
+	atomic.AddInt32(w.active, -1)
+	return nil
+}
+
+func TestSetCompressionLimits(t *testing.T) {
+	defer SetCompressionLimits(CompressionLimits{})
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"2021-09-01.log", "2021-09-02.log", "2021-09-03.log", "2021-09-04.log", "2021-09-05.log"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), make([]byte, 100), 0644))
+	}
+
+	var active, max int32
+	RegisterCompressor("counting", countingCompressor{active: &active, max: &max})
+	before := GetCompressionStats()
+	SetCompressionLimits(CompressionLimits{Workers: 2, BytesPerSecond: 1000})
+	l := &Logger{Directory: dir, MaxDays: 1, Compression: "counting"}
+	start := time.Now()
+	assert.Nil(t, l.config().millRunOnce())
+
+	// 4 files of 100 bytes, 2 at a time, at 1000 bytes per second
+	assert.Equal(t, int32(2), max)
+	assert.True(t, time.Since(start) >= 150*time.Millisecond)
+	after := GetCompressionStats()
+	assert.Equal(t, uint64(4), after.Files-before.Files)
+	assert.Equal(t, uint64(400), after.Bytes-before.Bytes)
+	assert.Equal(t, before.Failures, after.Failures)
+	assert.True(t, after.Duration > before.Duration)
+}
+
+func TestLogger_UnknownCompressionKeepsRetention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"2021-09-01.log", "2021-09-02.log", "2021-09-03.log"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("a\n"), 0644))
+	}
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxBackups: 1, Compression: "zpaq"}
+	err = l.config().millRunOnce()
+	assert.True(t, errors.Is(err, ErrCompression))
+	assert.Contains(t, err.Error(), `unknown compression "zpaq"`)
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+	assert.Equal(t, "2021-09-03.log", files[0].Name())
+}
@@ -0,0 +1,164 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Config describes a logger in a configuration file, see NewEasyLoggerFromConfig, e.g.
+//
+//	{
+//	  "level": "info",
+//	  "format": "text",
+//	  "directory": "./Logs/",
+//	  "rotate_days": 1,
+//	  "max_backups": 30,
+//	  "local_time": true,
+//	  "flags": ["date", "microseconds"],
+//	  "console": true
+//	}
+type Config struct {
+	Level  Level  `json:"level" yaml:"level" toml:"level"`
+	Format Format `json:"format" yaml:"format" toml:"format"`
+
+	// File is the size rotating log file, Directory the directory of the time rotating log
+	// files, see WithFile and WithDirectory.
+	File       string `json:"file" yaml:"file" toml:"file"`
+	Directory  string `json:"directory" yaml:"directory" toml:"directory"`
+	MaxSize    int    `json:"max_size" yaml:"max_size" toml:"max_size"`
+	MaxAge     int    `json:"max_age" yaml:"max_age" toml:"max_age"`
+	MaxBackups int    `json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+	RotateDays int    `json:"rotate_days" yaml:"rotate_days" toml:"rotate_days"`
+	LocalTime  bool   `json:"local_time" yaml:"local_time" toml:"local_time"`
+	Compress   bool   `json:"compress" yaml:"compress" toml:"compress"`
+	Shared     bool   `json:"shared_files" yaml:"shared_files" toml:"shared_files"`
+	DualFormat bool   `json:"dual_format" yaml:"dual_format" toml:"dual_format"`
+	ErrorFile  bool   `json:"error_file" yaml:"error_file" toml:"error_file"`
+	Console    bool   `json:"console" yaml:"console" toml:"console"`
+
+	// ConsoleLevel is the minimum level of the entries written to the console, see WithConsoleLevel.
+	ConsoleLevel Level `json:"console_level" yaml:"console_level" toml:"console_level"`
+
+	// Prefix and Flags are the prefix and the log.Logger flags of the text lines, the flags
+	// are named after the log constants without the L, e.g. "date", "microseconds", "shortfile".
+	Prefix string   `json:"prefix" yaml:"prefix" toml:"prefix"`
+	Flags  []string `json:"flags" yaml:"flags" toml:"flags"`
+}
+
+// ConfigDecoder decodes the content of a configuration file into v, like json.Unmarshal
+type ConfigDecoder func(data []byte, v interface{}) error
+
+var configDecoders = struct {
+	sync.RWMutex
+	m map[string]ConfigDecoder
+}{m: map[string]ConfigDecoder{".json": json.Unmarshal}}
+
+// RegisterConfigDecoder makes NewEasyLoggerFromConfig decode the files with the extension ext
+// with decode. JSON is built in, YAML (.yaml and .yml) with -tags yaml and TOML with -tags toml,
+// the other formats, or other libraries, are added with e.g.
+//
+//	easylogger.RegisterConfigDecoder(".hcl", hcl.Unmarshal)
+func RegisterConfigDecoder(ext string, decode ConfigDecoder) {
+	configDecoders.Lock()
+	defer configDecoders.Unlock()
+	configDecoders.m[strings.ToLower(ext)] = decode
+}
+
+var flagNames = map[string]int{
+	"date":         log.Ldate,
+	"time":         log.Ltime,
+	"microseconds": log.Lmicroseconds,
+	"longfile":     log.Llongfile,
+	"shortfile":    log.Lshortfile,
+	"utc":          log.LUTC,
+	"msgprefix":    log.Lmsgprefix,
+	"stdflags":     log.LstdFlags,
+}
+
+// parseFlags returns the log.Logger flags named in names
+func parseFlags(names []string) (int, error) {
+	flags := 0
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		f, ok := flagNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown flag %q", name)
+		}
+		flags |= f
+	}
+	return flags, nil
+}
+
+// ReadConfig reads the configuration file path, decoded according to its extension.
+func ReadConfig(path string) (*Config, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	configDecoders.RLock()
+	decode, ok := configDecoders.m[ext]
+	configDecoders.RUnlock()
+	if !ok {
+		return nil, coded(CodeConfig, fmt.Errorf("no decoder for the config file %s, see RegisterConfigDecoder", path))
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, coded(CodeConfig, err)
+	}
+	c := &Config{}
+	if err = decode(data, c); err != nil {
+		return nil, coded(CodeConfig, fmt.Errorf("can't decode the config file %s: %s", path, err))
+	}
+	return c, nil
+}
+
+// Options returns the options of NewEasyLogger matching the configuration.
+func (c *Config) Options() ([]Option, error) {
+	flags, err := parseFlags(c.Flags)
+	if err != nil {
+		return nil, coded(CodeConfig, err)
+	}
+	opts := []Option{
+		WithLevel(c.Level),
+		WithFormat(c.Format),
+		WithFile(c.File),
+		WithDirectory(c.Directory),
+		WithMaxSize(c.MaxSize),
+		WithMaxAge(c.MaxAge),
+		WithMaxBackups(c.MaxBackups),
+		WithLocalTime(c.LocalTime),
+		WithCompress(c.Compress),
+		WithSharedFiles(c.Shared),
+		WithDualFormat(c.DualFormat),
+		WithErrorFile(c.ErrorFile),
+		WithConsole(c.Console),
+		WithConsoleLevel(c.ConsoleLevel),
+		WithPrefix(c.Prefix),
+		WithFlags(flags),
+	}
+	if c.RotateDays > 0 {
+		opts = append(opts, WithRotateDays(c.RotateDays))
+	}
+	return opts, nil
+}
+
+// NewEasyLoggerFromConfig creates an EasyLogger described by the configuration file path,
+// so that the logging can change without recompiling. opts are applied after the configuration.
+func NewEasyLoggerFromConfig(path string, opts ...Option) (*EasyLogger, error) {
+	c, err := ReadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	configured, err := c.Options()
+	if err != nil {
+		return nil, coded(CodeConfig, fmt.Errorf("invalid config file %s: %s", path, errors.Unwrap(err)))
+	}
+	return NewEasyLogger(append(configured, opts...)...), nil
+}
@@ -0,0 +1,12 @@
+// +build toml
+
+package easylogger
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+// the TOML configuration files are decoded with github.com/BurntSushi/toml when built with -tags toml
+func init() {
+	RegisterConfigDecoder(".toml", toml.Unmarshal)
+}
@@ -0,0 +1,42 @@
+// +build toml
+
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadConfig_TOML(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log.toml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`
+level = "warn"
+format = "json"
+directory = "./Logs/"
+max_backups = 3
+local_time = true
+flags = ["date", "microseconds"]
+`), 0644))
+	c, err := ReadConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, WarnLevel, c.Level)
+	assert.Equal(t, JSONFormat, c.Format)
+	assert.Equal(t, "./Logs/", c.Directory)
+	assert.Equal(t, 3, c.MaxBackups)
+	assert.True(t, c.LocalTime)
+	flags, err := parseFlags(c.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, log.Ldate|log.Lmicroseconds, flags)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`level = "verbose"`), 0644))
+	_, err = ReadConfig(path)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,13 @@
+// +build yaml
+
+package easylogger
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// the YAML configuration files are decoded with gopkg.in/yaml.v3 when built with -tags yaml
+func init() {
+	RegisterConfigDecoder(".yaml", yaml.Unmarshal)
+	RegisterConfigDecoder(".yml", yaml.Unmarshal)
+}
@@ -0,0 +1,67 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewEasyLoggerFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "log.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{
+		"level": "warn",
+		"format": "json",
+		"directory": "`+filepath.ToSlash(dir)+`",
+		"max_backups": 3,
+		"flags": ["date", "Microseconds"]
+	}`), 0644))
+
+	l, err := NewEasyLoggerFromConfig(path, WithPrefix("app "))
+	assert.Nil(t, err)
+	assert.Equal(t, WarnLevel, l.GetLevel())
+	assert.Equal(t, JSONFormat, l.GetFormat())
+	assert.Equal(t, log.Ldate|log.Lmicroseconds, l.flags)
+	assert.Equal(t, "app ", l.prefix)
+	assert.Equal(t, 3, l.files[0].(*Logger).MaxBackups)
+	assert.Equal(t, 1, l.files[0].(*Logger).MaxDays)
+	l.Info("dropped")
+	l.Error("kept")
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(b), `{"time":`))
+	assert.Equal(t, 1, strings.Count(string(b), "\n"))
+
+	// the decoders are chosen by extension
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "log.conf"), nil, 0644))
+	_, err = NewEasyLoggerFromConfig(filepath.Join(dir, "log.conf"))
+	assert.NotNil(t, err)
+	RegisterConfigDecoder(".CONF", func(data []byte, v interface{}) error {
+		v.(*Config).Level = ErrorLevel
+		return nil
+	})
+	defer func() {
+		configDecoders.Lock()
+		delete(configDecoders.m, ".conf")
+		configDecoders.Unlock()
+	}()
+	l, err = NewEasyLoggerFromConfig(filepath.Join(dir, "log.conf"))
+	assert.Nil(t, err)
+	assert.Equal(t, ErrorLevel, l.GetLevel())
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"flags": ["nanoseconds"]}`), 0644))
+	_, err = NewEasyLoggerFromConfig(path)
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"level": "verbose"}`), 0644))
+	_, err = NewEasyLoggerFromConfig(path)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,205 @@
+package easylogger
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CanceledPolicy tells what happens to the TRACE, DEBUG and INFO entries logged with a
+// canceled context, e.g. by the handler of an aborted request. WARN and above are always kept.
+type CanceledPolicy int32
+
+const (
+	// CanceledKeep logs the entries as usual
+	CanceledKeep CanceledPolicy = iota
+	// CanceledDowngrade logs the DEBUG and INFO entries at the TRACE level
+	CanceledDowngrade
+	// CanceledSuppress drops the entries
+	CanceledSuppress
+)
+
+// SetCanceledPolicy sets what happens to the entries below WARN logged with a canceled context
+// by the Ctx methods. It should be called before the logger is used.
+func (this *EasyLogger) SetCanceledPolicy(p CanceledPolicy) {
+	this.canceled = p
+}
+
+// WithCanceledPolicy sets what happens to the entries logged with a canceled context.
+func WithCanceledPolicy(p CanceledPolicy) Option {
+	return func(l *EasyLogger) {
+		l.SetCanceledPolicy(p)
+	}
+}
+
+// ContextExtractor returns the fields to add to the entries logged with ctx, e.g. its request
+// ID, see AddContextExtractor
+type ContextExtractor func(ctx context.Context) Fields
+
+// ContextValue returns a ContextExtractor adding the value of key in the contexts as field,
+// e.g. ContextValue("request_id", requestIDKey{}). Nothing is added if ctx has no such value.
+func ContextValue(field string, key interface{}) ContextExtractor {
+	return func(ctx context.Context) Fields {
+		if v := ctx.Value(key); v != nil {
+			return Fields{field: v}
+		}
+		return nil
+	}
+}
+
+// AddContextExtractor makes the Ctx methods add the fields returned by e for their context to
+// their entries, so that the entries of a request can be correlated without passing its IDs
+// at each call site:
+//
+//	l.AddContextExtractor(easylogger.ContextValue("request_id", requestIDKey{}))
+//	l.AddContextExtractor(easylogger.ContextValue("tenant", tenantKey{}))
+//	l.InfoCtx(ctx, "order placed") // ... order placed request_id=42 tenant=acme
+//
+// It should be called before the logger is used.
+func (this *EasyLogger) AddContextExtractor(e ContextExtractor) {
+	this.extractors = append(this.extractors[:len(this.extractors):len(this.extractors)], e)
+}
+
+// WithContextExtractor adds a ContextExtractor, see AddContextExtractor.
+func WithContextExtractor(e ContextExtractor) Option {
+	return func(l *EasyLogger) {
+		l.AddContextExtractor(e)
+	}
+}
+
+// builtinExtractors are used by all the loggers before their own, they are only set by the
+// optional integrations, e.g. with OpenTelemetry, from their init function
+var builtinExtractors []ContextExtractor
+
+// ctxLogger returns a child logger adding the fields extracted from ctx, or this logger if
+// there are none
+func (this *EasyLogger) ctxLogger(ctx context.Context) *EasyLogger {
+	if (len(this.extractors) == 0 && len(builtinExtractors) == 0) || ctx == nil {
+		return this
+	}
+	var fields Fields
+	for _, e := range append(builtinExtractors[:len(builtinExtractors):len(builtinExtractors)], this.extractors...) {
+		for k, v := range e(ctx) {
+			if fields == nil {
+				fields = Fields{}
+			}
+			fields[k] = v
+		}
+	}
+	if fields == nil {
+		return this
+	}
+	return this.WithFields(fields)
+}
+
+// ctxLevel returns the level of an entry logged with ctx, and whether it is logged at all
+func (this *EasyLogger) ctxLevel(ctx context.Context, level Level) (Level, bool) {
+	if level < WarnLevel && this.canceled != CanceledKeep && ctx != nil && ctx.Err() != nil {
+		if this.canceled == CanceledSuppress {
+			return level, false
+		}
+		level = TraceLevel
+	}
+	return level, this.Enabled(level)
+}
+
+func (this *EasyLogger) TraceCtx(ctx context.Context, a ...interface{}) {
+	level, ok := this.ctxLevel(ctx, TraceLevel)
+	if !ok {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		funcName := strings.TrimPrefix(filepath.Ext(f.Function), ".")
+		a = append([]interface{}{funcName + "()", callerLocation(f)}, a...)
+	}
+	this.ctxLogger(ctx).output(level, a...)
+}
+
+func (this *EasyLogger) TracefCtx(ctx context.Context, format string, a ...interface{}) {
+	level, ok := this.ctxLevel(ctx, TraceLevel)
+	if !ok {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		funcName := strings.TrimPrefix(filepath.Ext(f.Function), ".")
+		a = append([]interface{}{funcName, callerLocation(f)}, a...)
+		format = "%s() %s " + format
+	}
+	this.ctxLogger(ctx).outputf(level, format, a...)
+}
+
+func (this *EasyLogger) DebugCtx(ctx context.Context, a ...interface{}) {
+	level, ok := this.ctxLevel(ctx, DebugLevel)
+	if !ok {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		a = append([]interface{}{f.Function, callerLocation(f)}, a...)
+	}
+	this.ctxLogger(ctx).output(level, a...)
+}
+
+func (this *EasyLogger) DebugfCtx(ctx context.Context, format string, a ...interface{}) {
+	level, ok := this.ctxLevel(ctx, DebugLevel)
+	if !ok {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		a = append([]interface{}{f.Function, callerLocation(f)}, a...)
+		format = "%s() %s " + format
+	}
+	this.ctxLogger(ctx).outputf(level, format, a...)
+}
+
+func (this *EasyLogger) InfoCtx(ctx context.Context, a ...interface{}) {
+	if level, ok := this.ctxLevel(ctx, InfoLevel); ok {
+		this.ctxLogger(ctx).output(level, a...)
+	}
+}
+
+func (this *EasyLogger) InfofCtx(ctx context.Context, format string, a ...interface{}) {
+	if level, ok := this.ctxLevel(ctx, InfoLevel); ok {
+		this.ctxLogger(ctx).outputf(level, format, a...)
+	}
+}
+
+func (this *EasyLogger) WarnCtx(ctx context.Context, a ...interface{}) {
+	this.ctxLogger(ctx).output(WarnLevel, a...)
+}
+
+func (this *EasyLogger) WarnfCtx(ctx context.Context, format string, a ...interface{}) {
+	this.ctxLogger(ctx).outputf(WarnLevel, format, a...)
+}
+
+func (this *EasyLogger) ErrorCtx(ctx context.Context, a ...interface{}) {
+	this.ctxLogger(ctx).output(ErrorLevel, a...)
+}
+
+func (this *EasyLogger) ErrorfCtx(ctx context.Context, format string, a ...interface{}) {
+	this.ctxLogger(ctx).outputf(ErrorLevel, format, a...)
+}
+
+func (this *EasyLogger) FatalCtx(ctx context.Context, a ...interface{}) {
+	this.ctxLogger(ctx).output(FatalLevel, a...)
+	this.exit(1)
+}
+
+func (this *EasyLogger) FatalfCtx(ctx context.Context, format string, a ...interface{}) {
+	this.ctxLogger(ctx).outputf(FatalLevel, format, a...)
+	this.exit(1)
+}
+
+func (this *EasyLogger) PanicCtx(ctx context.Context, a ...interface{}) {
+	this.ctxLogger(ctx).output(PanicLevel, a...)
+	panic(strings.TrimSuffix(fmt.Sprintln(a...), "\n"))
+}
+
+func (this *EasyLogger) PanicfCtx(ctx context.Context, format string, a ...interface{}) {
+	this.ctxLogger(ctx).outputf(PanicLevel, format, a...)
+	panic(fmt.Sprintf(format, a...))
+}
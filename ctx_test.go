@@ -0,0 +1,97 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_CanceledPolicy(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetLevel(DebugLevel)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l.SetCanceledPolicy(CanceledDowngrade)
+	l.InfoCtx(ctx, "live")
+	l.DebugfCtx(ctx, "debug %d", 1)
+	cancel()
+	l.InfoCtx(ctx, "downgraded")
+	l.SetLevel(TraceLevel)
+	l.InfofCtx(ctx, "downgraded %s", "again")
+	l.WarnCtx(ctx, "kept")
+
+	d := l.Clone(WithCanceledPolicy(CanceledSuppress))
+	d.InfoCtx(ctx, "suppressed")
+	d.TraceCtx(ctx, "suppressed")
+	d.ErrorfCtx(ctx, "kept %s", "too")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Contains(t, lines[0], INFO)
+	assert.Contains(t, lines[1], DEBUG)
+	assert.Contains(t, lines[1], "TestEasyLogger_CanceledPolicy() ctx_test.go:")
+	assert.Contains(t, lines[2], TRACE)
+	assert.Contains(t, lines[2], "downgraded again")
+	assert.Contains(t, lines[3], WARN)
+	assert.Contains(t, lines[4], "kept too")
+	assert.NotContains(t, buf.String(), "suppressed")
+}
+
+// debugThroughHelper is a helper of the application wrapping the logger
+func debugThroughHelper(ctx context.Context, l *EasyLogger, msg string) {
+	l.DebugCtx(ctx, msg)
+}
+
+func TestEasyLogger_CtxCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetLevel(TraceLevel)
+	ctx := context.Background()
+
+	// the caller of the helper
+	debugThroughHelper(ctx, l.Clone(WithCallerSkip(1)), "wrapped")
+	assert.Regexp(t, `EasyLogger/v2\.TestEasyLogger_CtxCaller ctx_test\.go:\d+ wrapped`, buf.String())
+
+	// no duplicated caller with EnableCaller
+	buf.Reset()
+	l.EnableCaller(true)
+	l.TracefCtx(ctx, "%d", 42)
+	l.DebugCtx(ctx, "debug")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Equal(t, 1, strings.Count(line, "ctx_test.go:"))
+		assert.Contains(t, line, "v2.TestEasyLogger_CtxCaller()")
+	}
+}
+
+type requestIDKey struct{}
+
+func TestEasyLogger_AddContextExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), flags: log.Lshortfile}
+	l.AddContextExtractor(ContextValue("request_id", requestIDKey{}))
+	c := l.Clone(WithContextExtractor(func(ctx context.Context) Fields {
+		return Fields{"tenant": "acme"}
+	}))
+	ctx := context.WithValue(context.Background(), requestIDKey{}, 42)
+
+	l.InfoCtx(ctx, "placed")
+	l.InfoCtx(context.Background(), "no request")
+	c.ErrorfCtx(ctx, "failed %d", 1)
+	c.Begin(ctx, "charge", nil)(nil)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "ctx_test.go:"))
+	assert.True(t, strings.HasSuffix(lines[0], ", placed request_id=42"))
+	assert.True(t, strings.HasSuffix(lines[1], ", no request"))
+	assert.True(t, strings.HasSuffix(lines[2], ", failed 1 request_id=42 tenant=acme"))
+	assert.Contains(t, lines[3], "charge succeeded")
+	assert.Contains(t, lines[3], "request_id=42")
+	// the extractors of the clone are its own
+	assert.Len(t, l.extractors, 1)
+}
@@ -0,0 +1,54 @@
+package easylogger
+
+import (
+	"sync"
+)
+
+// Decoration is added around the messages of a level, e.g. Decoration{Suffix: " [oncall-page]"}
+// for the alerting systems keying on tokens.
+type Decoration struct {
+	Prefix string
+	Suffix string
+}
+
+// decorations are the Decorations of the levels, never modified once stored
+type decorations [len(levelNames)]Decoration
+
+// decorationsMu serializes the updates of the decorations of all the loggers, they are rare
+var decorationsMu sync.Mutex
+
+// SetDecoration sets the Decoration of the messages of level, it is safe to call at any time.
+// The zero Decoration removes it.
+func (this *EasyLogger) SetDecoration(level Level, d Decoration) {
+	if level < TraceLevel || level > PanicLevel {
+		this.misuse("invalid level %s", level)
+		return
+	}
+	decorationsMu.Lock()
+	defer decorationsMu.Unlock()
+
+	var ds decorations
+	if old, _ := this.decorations.Load().(*decorations); old != nil {
+		ds = *old
+	}
+	ds[level] = d
+	this.decorations.Store(&ds)
+}
+
+// WithDecoration sets the Decoration of the messages of level.
+func WithDecoration(level Level, d Decoration) Option {
+	return func(l *EasyLogger) {
+		l.SetDecoration(level, d)
+	}
+}
+
+// decorate adds the Decoration of its level to the message of r
+func (this *EasyLogger) decorate(r *record) {
+	ds, _ := this.decorations.Load().(*decorations)
+	if ds == nil || r.Level < TraceLevel || r.Level > PanicLevel {
+		return
+	}
+	if d := ds[r.Level]; d != (Decoration{}) {
+		r.Message = d.Prefix + r.Message + d.Suffix
+	}
+}
@@ -0,0 +1,28 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_SetDecoration(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetDecoration(ErrorLevel, Decoration{Prefix: "🔥 ", Suffix: " [oncall-page]"})
+	c := l.Clone()
+
+	l.Info("fine")
+	l.Error("disk full")
+	l.SetDecoration(ErrorLevel, Decoration{})
+	l.Error("disk full again")
+	c.Error("from the clone")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.True(t, strings.HasSuffix(lines[0], ", fine"))
+	assert.True(t, strings.HasSuffix(lines[1], ", 🔥 disk full [oncall-page]"))
+	assert.True(t, strings.HasSuffix(lines[2], ", disk full again"))
+	assert.True(t, strings.HasSuffix(lines[3], ", 🔥 from the clone [oncall-page]"))
+}
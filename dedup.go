@@ -0,0 +1,85 @@
+package easylogger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RepeatedField is the field of the number of repetitions in a "last message repeated" entry
+const RepeatedField = "repeated"
+
+// dedup collapses the consecutive identical entries of a logger and its clones
+type dedup struct {
+	window time.Duration
+	report func(level Level, n int) // logs the repetitions with the logger of SetDeduplication
+
+	mu       sync.Mutex
+	active   bool
+	level    Level
+	message  string
+	fields   string // the Fields of the entry, rendered with their keys sorted
+	since    time.Time // time the last distinct entry was written
+	repeated int
+	gen      uint64 // incremented with every distinct entry, so that stale timers are ignored
+}
+
+// SetDeduplication collapses the consecutive entries having the same level, message and fields, written
+// within window of the first one, syslog-style: the first one is written, then, when a
+// different entry is logged or window has elapsed, a "last message repeated N times" entry of
+// the same level, with a "repeated" field. A window of zero turns the deduplication off.
+// The logger and its clones share the deduplication. It should be called before the logger is
+// used.
+func (this *EasyLogger) SetDeduplication(window time.Duration) {
+	if window <= 0 {
+		this.dedup = nil
+		return
+	}
+	this.dedup = &dedup{window: window, report: func(level Level, n int) {
+		this.logSummary(level, fmt.Sprintf("last message repeated %d times", n), Fields{RepeatedField: n})
+	}}
+}
+
+// deduplicate drops the record if it repeats the last one
+func (this *EasyLogger) deduplicate(r *record) bool {
+	d := this.dedup
+	if d == nil || r.summary || r.Level < this.GetLevel() {
+		return true
+	}
+	now := time.Now()
+	fields := r.Fields.String()
+	d.mu.Lock()
+	if d.active && r.Level == d.level && r.Message == d.message && fields == d.fields && now.Sub(d.since) < d.window {
+		d.repeated++
+		if d.repeated == 1 {
+			gen := d.gen
+			time.AfterFunc(d.window-now.Sub(d.since), func() { d.flush(gen) })
+		}
+		d.mu.Unlock()
+		return false
+	}
+	level, n := d.level, d.repeated
+	d.active, d.level, d.message, d.fields, d.since, d.repeated = true, r.Level, r.Message, fields, now, 0
+	d.gen++
+	d.mu.Unlock()
+
+	if n > 0 {
+		d.report(level, n)
+	}
+	return true
+}
+
+// flush reports the repetitions once the window of the entry of gen has elapsed
+func (d *dedup) flush(gen uint64) {
+	d.mu.Lock()
+	if gen != d.gen || d.repeated == 0 {
+		d.mu.Unlock()
+		return
+	}
+	level, n := d.level, d.repeated
+	d.active, d.repeated = false, 0
+	d.gen++
+	d.mu.Unlock()
+
+	d.report(level, n)
+}
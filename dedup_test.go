@@ -0,0 +1,56 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_SetDeduplication(t *testing.T) {
+	var buf syncBuffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetDeduplication(time.Hour)
+
+	for i := 0; i < 4; i++ {
+		l.Error("connection refused")
+	}
+	l.Warn("connection refused") // not the same level
+	l.Warn("connection refused")
+	l.With("i", 1).Info("done") // the clones share the deduplication
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 5)
+	assert.Contains(t, lines[0], "connection refused")
+	assert.Contains(t, lines[1], "[ERROR]")
+	assert.Contains(t, lines[1], "last message repeated 3 times repeated=3")
+	assert.Contains(t, lines[2], "[WARN ]")
+	assert.Contains(t, lines[3], "[WARN ]")
+	assert.Contains(t, lines[3], "last message repeated 1 times repeated=1")
+	assert.Contains(t, lines[4], "done i=1")
+
+	// the entries differing by their fields are all written
+	buf = syncBuffer{}
+	l.With("user", 1).Error("denied")
+	l.With("user", 2).Error("denied")
+	l.With("user", 2).Error("denied")
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "denied user=1")
+	assert.Contains(t, lines[1], "denied user=2")
+
+	// the repetitions are reported once the window has elapsed
+	buf = syncBuffer{}
+	l.SetDeduplication(50 * time.Millisecond)
+	l.Info("tick")
+	l.Info("tick")
+	l.Info("tick")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	time.Sleep(200 * time.Millisecond)
+	assert.Contains(t, buf.String(), "last message repeated 2 times")
+	l.Info("tick")
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+
+	l.SetDeduplication(0)
+	l.Info("tick")
+	assert.Equal(t, 4, strings.Count(buf.String(), "\n"))
+}
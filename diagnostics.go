@@ -0,0 +1,95 @@
+package easylogger
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DiagnosticsFileExt is the extension of the diagnostics files, see EnableDiagnostics
+	DiagnosticsFileExt = ".diag"
+
+	// the diagnostics files are rotated daily or at 1 megabyte, and only a few are kept
+	diagnosticsMaxSize    = 1
+	diagnosticsMaxBackups = 3
+)
+
+// diagnostics is the *Logger of the internal diagnostics, if enabled
+var diagnostics atomic.Value
+
+// errorHandler is the func(error) of SetErrorHandler, if set
+var errorHandler atomic.Value
+
+// EnableDiagnostics writes the internal diagnostics of all the loggers, e.g. the failed writes
+// and rotations or the rejected entries, into small "<date>.diag" files in dir, separate from
+// the log files so that a broken pipeline doesn't hide its own failures. At most a few
+// megabytes are kept. An empty dir disables the diagnostics.
+func EnableDiagnostics(dir string) {
+	var l *Logger
+	if dir != "" {
+		l = &Logger{
+			Directory:  dir,
+			MaxDays:    1,
+			MaxSize:    diagnosticsMaxSize,
+			MaxBackups: diagnosticsMaxBackups,
+			FileExt:    DiagnosticsFileExt,
+		}
+	}
+	if old, _ := diagnostics.Load().(*Logger); old != nil {
+		defer old.Close()
+	}
+	diagnostics.Store(l)
+}
+
+// SetErrorHandler makes the internal failures of all the loggers, e.g. the failed writes on a
+// full disk, the failed rotations or compressions, be reported to h as they happen, along with
+// the diagnostics. The errors are *LoggerError, with the underlying error as their cause:
+//
+//	easylogger.SetErrorHandler(func(err error) {
+//		if errors.Is(err, syscall.ENOSPC) {
+//			alert("the disk of the logs is full")
+//		}
+//	})
+//
+// h is called synchronously by the failing logger, it should be fast and must not log with it.
+// A nil h removes the handler.
+func SetErrorHandler(h func(err error)) {
+	errorHandler.Store(h)
+}
+
+// diagError is the cause of the errors given to the handler of SetErrorHandler, the message of
+// the diagnostic wrapping its error argument
+type diagError struct {
+	msg string
+	err error
+}
+
+func (e *diagError) Error() string {
+	return e.msg
+}
+
+func (e *diagError) Unwrap() error {
+	return e.err
+}
+
+// diagf counts a failure of code for GetErrorStats, writes a line with the code into the
+// diagnostics files, if enabled, and reports it to the handler of SetErrorHandler, if set
+func diagf(code ErrorCode, format string, a ...interface{}) {
+	countError(code)
+	msg := fmt.Sprintf(format, a...)
+	if h, _ := errorHandler.Load().(func(error)); h != nil {
+		cause := &diagError{msg: msg}
+		for i := len(a) - 1; i >= 0 && cause.err == nil; i-- {
+			cause.err, _ = a[i].(error)
+		}
+		h(&LoggerError{Code: code, Err: cause})
+	}
+	l, _ := diagnostics.Load().(*Logger)
+	if l == nil {
+		return
+	}
+	line := time.Now().Format("2006/01/02 15:04:05.000000 ") + "[" + string(code) + "] " + msg + "\n"
+	// nowhere to report a failure
+	_, _ = l.Write([]byte(line))
+}
@@ -0,0 +1,59 @@
+package easylogger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnableDiagnostics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	EnableDiagnostics(dir)
+	defer EnableDiagnostics("")
+	l := &EasyLogger{out: newSink(newMultiWriter(failingWriter{}))}
+	l.Info("lost")
+	SetFieldPolicy(&FieldPolicy{Required: []string{"service"}, Reject: true})
+	l.Info("rejected")
+	SetFieldPolicy(nil)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+DiagnosticsFileExt))
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], "[EL_WRITE] INFO entry not written")
+	assert.Contains(t, lines[1], "[EL_POLICY] INFO entry rejected, missing fields service")
+
+	// no file is written once disabled
+	EnableDiagnostics("")
+	diagf(CodeWrite, "ignored")
+	b, _ = ioutil.ReadFile(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+DiagnosticsFileExt))
+	assert.NotContains(t, string(b), "ignored")
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	var errs []error
+	SetErrorHandler(func(err error) { errs = append(errs, err) })
+	defer SetErrorHandler(nil)
+
+	l := &EasyLogger{out: newSink(newMultiWriter(failingWriter{}))}
+	l.Info("lost")
+	diagf(CodeRotation, "rotation in %s failed: %v", "/logs", os.ErrPermission)
+	assert.Equal(t, 2, len(errs))
+	assert.True(t, errors.Is(errs[0], ErrWrite))
+	assert.Contains(t, errs[0].Error(), "EL_WRITE: INFO entry not written: disk is gone")
+	assert.True(t, errors.Is(errs[1], ErrRotation))
+	assert.True(t, errors.Is(errs[1], os.ErrPermission))
+	assert.Equal(t, "EL_ROTATION: rotation in /logs failed: permission denied", errs[1].Error())
+
+	SetErrorHandler(nil)
+	l.Info("lost")
+	assert.Equal(t, 2, len(errs))
+}
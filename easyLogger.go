@@ -1,17 +1,17 @@
-package EasyLogger
+package easylogger
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"github.com/gookit/color"
-	"github.com/natefinch/lumberjack"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
-	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 const (
@@ -23,29 +23,115 @@ const (
 	WARN  = "[WARN ]"
 	ERROR = "[ERROR]"
 	FATAL = "[FATAL]"
+	PANIC = "[PANIC]"
 )
 
-var (
-	Trace = color.Cyan
-	Debug = color.Blue
-	Info  = color.Green
-	Warn  = color.Yellow
-	Error = color.Red
-	Fatal = color.Magenta
-)
-
-func GetGID() uint64 {
-	var buf [64]byte
-	b := buf[:runtime.Stack(buf[:], false)]
-	b = bytes.TrimPrefix(b, []byte("goroutine "))
-	b = b[:bytes.IndexByte(b, ' ')]
-	n, _ := strconv.ParseUint(string(b), 10, 64)
-	return n
+// EasyLogger writes its text lines with the same prefix and flags as a log.Logger
+type EasyLogger struct {
+	stages       [stageCount]stageState // first for the alignment of its 64-bit counters
+	strict       int32                  // 1 in strict mode, accessed atomically
+	lineFormat   int32                  // Format, accessed atomically
+	colorMode    int32                  // ColorMode, accessed atomically
+	maxMessage   int32                  // maximum length of the messages, accessed atomically
+	singleWriter int32                  // 1 in single writer mode, accessed atomically
+	writeFailure int32                  // WriteFailurePolicy, accessed atomically
+
+	out        *sink
+	console    *sink           // the console output, nil if not used
+	consoleTTY bool            // whether the console is a terminal
+	prefix     string          // written at the start of the text lines, or before the message with log.Lmsgprefix
+	flags      int             // log.Logger flags of the text lines
+	jsonOut    io.Writer       // optional JSON lines sidecar, nil if not used
+	encrypter  *FieldEncrypter // optional encryption of sensitive JSON fields
+
+	redactions []*regexp.Regexp
+	sampler    Sampler
+	callerOn   bool         // add the caller to the entries of all the levels, see EnableCaller
+	callerSkip int          // extra stack frames skipped to find the caller, see SetCallerSkip
+	noGID      bool         // the GID is left out of the entries, see EnableGID
+	stackOn    bool         // add the stack trace to the ERROR, FATAL and PANIC entries, see EnableStackTrace
+	errStack   string       // the error of WithError formatted with %+v, if it has a stack trace
+	formatter  Formatter    // renders the text lines in place of the default layout, see SetFormatter
+	dedup      *dedup       // shared with the clones, see SetDeduplication
+	limiter    *rateLimiter // shared with the clones, see SetRateLimit
+
+	dir     string            // directory of the log files
+	files   []io.Writer       // the log files writers, i.e. the outputs other than the console
+	buffers []*bufferedWriter // the buffers of the log files, see WithBuffer
+	syncer  *fileSyncer       // shared with the clones, see WithSyncPolicy
+
+	health health
+	recent *RecentBuffer // optional in-memory copy of the recent entries
+
+	profiler  *errorProfiler  // optional profiling of error storms
+	tracer    *selfTracer     // optional timing of the pipeline, see EnableSelfTrace
+	clock     *hybridClock    // optional source of the entry times, see SetHybridClock
+	async     *asyncQueue     // optional queue of the entries to write, see EnableAsync
+	canceled  CanceledPolicy  // entries logged with a canceled context, see SetCanceledPolicy
+	accessLog AccessLogFormat // layout of the entries of HTTPMiddleware, see SetAccessLogFormat
+	exitFunc  func(code int)  // called by Fatal to exit, os.Exit if nil
+	setup     *outputSetup    // outputs to create, only set while NewEasyLogger applies its options
+
+	level       atomic.Value // *sharedLevel, the minimum Level, see SetLevel
+	decorations atomic.Value // *decorations, see SetDecoration
+
+	fields     Fields             // added to every entry, never modified once set
+	labels     []string           // added to every entry, never modified once set
+	extractors []ContextExtractor // fields of the entries logged with a context, never modified once set
+	routes     []route
+	sinks      *entrySinks // shared with the clones, see AddSink
+	hooks      *levelHooks // shared with the clones, see AddHook
+	captures   *captures   // shared with the clones, see CaptureWindow
+	metrics    *logMetrics // shared with the clones, see Stats
 }
 
-// EasyLogger uses log.Logger inside
-type EasyLogger struct {
-	logger *log.Logger
+// NewEasyLogger creates an EasyLogger configured by opts, e.g.
+//
+//	l := NewEasyLogger(WithFile("./Logs/app.log"), WithMaxSize(10), WithConsole(true), WithLevel(InfoLevel))
+//
+// WithFile writes to a size rotating file and WithDirectory to time rotating files, without
+// either the entries are written to the console.
+func NewEasyLogger(opts ...Option) *EasyLogger {
+	el := &EasyLogger{setup: &outputSetup{rotateDays: 1}, sinks: &entrySinks{}, hooks: &levelHooks{}, captures: &captures{min: noCapture}, metrics: &logMetrics{}}
+	for _, opt := range opts {
+		opt(el)
+	}
+	s := el.setup
+	el.setup = nil
+
+	switch {
+	case s.fileName != "":
+		lum := s.sizeRotatingFile(s.fileName)
+		el.dir = filepath.Dir(s.fileName)
+		el.files = []io.Writer{lum}
+		el.out = newSink(newMultiWriter(el.buffered(lum, s)))
+		if s.errorFile {
+			el.addErrorFile(s.sizeRotatingFile(errorFileName(s.fileName)), s)
+		}
+	case s.dir != "":
+		tl := s.timeRotatingFile("")
+		el.dir = s.dir
+		el.files = []io.Writer{tl}
+		el.out = newSink(newMultiWriter(el.buffered(tl, s)))
+		if s.dualFormat {
+			jl := s.timeRotatingFile(JSONFileNameExt)
+			// the JSON sidecar is a log file, but not a text output
+			el.files = append(el.files, jl)
+			el.jsonOut = el.buffered(jl, s)
+		}
+		if s.errorFile {
+			el.addErrorFile(s.timeRotatingFile(ErrorFileNameExt), s)
+		}
+	default:
+		s.console = true
+		el.out = newSink(newMultiWriter())
+	}
+
+	if s.console {
+		el.setConsole(os.Stdout)
+		el.console.min = s.consoleMin
+	}
+	return el
 }
 
 func NewSizeRotatingEasyLogger(fileName string,
@@ -58,22 +144,9 @@ func NewSizeRotatingEasyLogger(fileName string,
 	prefixForLogger string,
 	needConsoleOut bool) *EasyLogger {
 
-	lum := &lumberjack.Logger{
-		Filename:   fileName,
-		MaxSize:    maxFileSize,    // defaults to 100 megabytes
-		MaxAge:     maxBackupAge,   // maximum number of days to retain old log files based on the timestamp encoded in their filename, default is not to remove old log files
-		MaxBackups: maxBackupFiles, // maximum number of old log files to retain, default is to retain all old log files
-		LocalTime:  useLocalTime,   // default is to use UTC time
-		Compress:   useCompression, // compress the rotated files, default is not to compress
-	}
-	ws := []io.Writer{lum}
-	if needConsoleOut {
-		ws = append(ws, os.Stdout)
-	}
-	outs := io.MultiWriter(ws...)
-	ll := log.New(outs, prefixForLogger, lineFlag)
-
-	return &EasyLogger{logger: ll}
+	return NewEasyLogger(WithFile(fileName), WithMaxSize(maxFileSize), WithMaxAge(maxBackupAge),
+		WithMaxBackups(maxBackupFiles), WithLocalTime(useLocalTime), WithCompress(useCompression),
+		WithFlags(lineFlag), WithPrefix(prefixForLogger), WithConsole(needConsoleOut))
 }
 
 func NewTimeRotatingEasyLogger(dirName string,
@@ -85,120 +158,397 @@ func NewTimeRotatingEasyLogger(dirName string,
 	prefixForLogger string,
 	needConsoleOut bool) *EasyLogger {
 
-	tl := &Logger{
-		Directory:   dirName,
-		MaxDays:     rotateDays,
-		MaxBackups:  maxBackupFiles,
-		LocalTime:   useLocalTime,
-		Compress:    useCompression,
+	return NewEasyLogger(WithDirectory(dirName), WithRotateDays(rotateDays), WithMaxBackups(maxBackupFiles),
+		WithLocalTime(useLocalTime), WithCompress(useCompression),
+		WithFlags(lineFlag), WithPrefix(prefixForLogger), WithConsole(needConsoleOut))
+}
+
+// NewTimeRotatingDualFormatEasyLogger works like NewTimeRotatingEasyLogger, and in addition
+// writes every entry as a JSON line into a ".jsonl" sidecar file next to each ".log" file,
+// rotated on the same schedule.
+func NewTimeRotatingDualFormatEasyLogger(dirName string,
+	rotateDays int,
+	maxBackupFiles int,
+	useLocalTime bool,
+	useCompression bool,
+	lineFlag int, // log.Ldate|log.Lmicroseconds
+	prefixForLogger string,
+	needConsoleOut bool) *EasyLogger {
+
+	return NewEasyLogger(WithDirectory(dirName), WithRotateDays(rotateDays), WithMaxBackups(maxBackupFiles),
+		WithLocalTime(useLocalTime), WithCompress(useCompression), WithDualFormat(true),
+		WithFlags(lineFlag), WithPrefix(prefixForLogger), WithConsole(needConsoleOut))
+}
+
+// setConsole makes f the console output
+func (this *EasyLogger) setConsole(f *os.File) {
+	this.console = newSink(f)
+	this.consoleTTY = isTerminal(f)
+}
+
+func (this *EasyLogger) output(level Level, a ...interface{}) error {
+	if !this.Enabled(level) {
+		return nil
 	}
+	msg := strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+
+	return this.emit(level, msg)
+}
 
-	ws := []io.Writer{tl}
-	if needConsoleOut {
-		ws = append(ws, os.Stdout)
+func (this *EasyLogger) outputf(level Level, format string, v ...interface{}) error {
+	if !this.Enabled(level) {
+		return nil
 	}
-	outs := io.MultiWriter(ws...)
-	ll := log.New(outs, prefixForLogger, lineFlag)
+	msg := fmt.Sprintf(format, v...)
+	this.checkFormat(format, msg)
 
-	return &EasyLogger{logger: ll}
+	return this.emit(level, msg)
 }
 
-func (this *EasyLogger) output(level string, a ...interface{}) error {
-	gid := GetGID()
-	gidStr := strconv.FormatUint(gid, 10)
+// emit runs the entry through the pipeline, see pipeline.go
+func (this *EasyLogger) emit(level Level, msg string) error {
+	if this.wantsStack(level) {
+		// emit is called by output or outputf, called by the level methods
+		return this.emitStack(level, msg, callers(4+this.callerSkip))
+	}
+	var pc uintptr
+	if this.needsCaller() {
+		pcs := [1]uintptr{}
+		runtime.Callers(4+this.callerSkip, pcs[:])
+		pc = pcs[0]
+	}
+	return this.emitAt(level, msg, pc)
+}
 
-	a = append([]interface{}{level, "GID", gidStr + ","}, a...)
+// needsCaller reports whether the entries include their caller
+func (this *EasyLogger) needsCaller() bool {
+	return this.callerOn || this.jsonOut != nil || this.GetFormat() != TextFormat ||
+		this.flags&(log.Lshortfile|log.Llongfile) != 0 || this.formatterNeedsCaller()
+}
 
-	return this.logger.Output(CALL_DEPTH, fmt.Sprintln(a...))
+// emitAt logs an entry called from the program counter pc, as returned by runtime.Callers
+func (this *EasyLogger) emitAt(level Level, msg string, pc uintptr) error {
+	this.checkClosed()
+	return this.process(this.newRecord(level, msg, pc))
 }
 
-func (this *EasyLogger) outputf(level string, format string, v ...interface{}) error {
-	gid := GetGID()
-	v = append([]interface{}{level, "GID", gid}, v...)
+// newRecord returns the record of an entry called from the program counter pc
+func (this *EasyLogger) newRecord(level Level, msg string, pc uintptr) *record {
+	if level >= ErrorLevel && this.profiler != nil {
+		this.profiler.observe(this)
+	}
+	now, fields := this.now()
+	e := Entry{
+		Time:    now,
+		Level:   level,
+		Message: msg,
+		Fields:  fields,
+		Labels:  this.labels,
+		Stack:   this.errStack,
+	}
+	r := &record{Entry: e}
+	if this.needsCaller() {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if pc != 0 && frame.File != "" {
+			r.Caller = callerLocation(frame)
+			r.file, r.fileLine = frame.File, frame.Line
+			if this.callerOn {
+				r.Function = shortFunction(frame.Function)
+			}
+		} else {
+			r.file = "???"
+		}
+	}
+	return r
+}
 
-	return this.logger.Output(CALL_DEPTH, fmt.Sprintf("%s %s %d, "+format+"\n", v...))
+// sharedLevel is the level of a logger, shared with its clones until they set their own
+type sharedLevel struct {
+	level int32 // accessed atomically
+	owner *EasyLogger
 }
 
-func (this *EasyLogger) Trace(a ...interface{}) {
-	pc := make([]uintptr, 10)
-	runtime.Callers(2, pc)
-	f := runtime.FuncForPC(pc[0])
-	file, line := f.FileLine(pc[0])
-	fileName := filepath.Base(file)
+// SetLevel sets the minimum level of the entries to log, it is safe to call at any time. The
+// clones which haven't set their own level follow it, e.g. when it is changed by LevelHandler.
+func (this *EasyLogger) SetLevel(level Level) {
+	if level < TraceLevel || level > PanicLevel {
+		this.misuse("invalid level %s", level)
+	}
+	if sl, _ := this.level.Load().(*sharedLevel); sl != nil && sl.owner == this {
+		atomic.StoreInt32(&sl.level, int32(level))
+		return
+	}
+	// the level of a clone is its own from now on
+	this.level.Store(&sharedLevel{level: int32(level), owner: this})
+}
 
-	nameFull := f.Name()
-	nameEnd := filepath.Ext(nameFull)
-	funcName := strings.TrimPrefix(nameEnd, ".")
+// GetLevel returns the minimum level of the entries to log.
+func (this *EasyLogger) GetLevel() Level {
+	if sl, _ := this.level.Load().(*sharedLevel); sl != nil {
+		return Level(atomic.LoadInt32(&sl.level))
+	}
+	return TraceLevel
+}
 
-	a = append([]interface{}{funcName + "()", fileName + ":" + strconv.Itoa(line)}, a...)
-	this.output(Trace.Sprint(TRACE), a...)
+// Enabled reports whether the entries of the given level are logged, or recorded by a CaptureWindow.
+func (this *EasyLogger) Enabled(level Level) bool {
+	return level >= this.GetLevel() || this.captures.wants(level)
 }
 
-func (this *EasyLogger) Tracef(format string, a ...interface{}) {
-	pc := make([]uintptr, 10)
-	runtime.Callers(2, pc)
-	f := runtime.FuncForPC(pc[0])
-	file, line := f.FileLine(pc[0])
-	fileName := filepath.Base(file)
+// SetFieldEncrypter makes the JSON outputs encrypt the sensitive fields with e,
+// nil turns the encryption off. It should be called before the logger is used.
+func (this *EasyLogger) SetFieldEncrypter(e *FieldEncrypter) {
+	this.encrypter = e
+}
 
-	nameFull := f.Name()
-	nameEnd := filepath.Ext(nameFull)
-	funcName := strings.TrimPrefix(nameEnd, ".")
+// Rotate forces the rotation of the log files, see Logger.Rotate and lumberjack.Logger.Rotate.
+// All the files are rotated even if one fails, the first error is returned.
+func (this *EasyLogger) Rotate() error {
+	err := this.flushBuffers()
+	for _, f := range this.files {
+		r, ok := f.(interface{ Rotate() error })
+		if !ok {
+			continue
+		}
+		if errRotate := r.Rotate(); err == nil {
+			err = errRotate
+		}
+	}
+	return err
+}
 
-	a = append([]interface{}{funcName, fileName, line}, a...)
-	this.outputf(Trace.Sprint(TRACE), "%s() %s:%d "+format, a...)
+// Shutdown writes the entries queued in asynchronous mode and stops its goroutine within the
+// deadline of ctx, dropping the ones still queued at the deadline, writes the buffered entries, then closes the log files once their writes in
+// progress are done, running a last compression pass of the time rotating ones within the
+// deadline of ctx and stopping their mill goroutine, see Logger.Shutdown. All the files are closed even if one fails, the
+// first error is returned. The EntrySinks, writers and routes added to the logger are closed
+// too if they are io.Closers, except os.Stdout and os.Stderr. The logger and its clones must
+// not be used after Shutdown.
+func (this *EasyLogger) Shutdown(ctx context.Context) error {
+	if this.out != nil {
+		atomic.StoreInt32(&this.out.closed, 1)
+	}
+	var err error
+	stopped := make(chan struct{})
+	go func() {
+		this.Flush()
+		if this.async != nil {
+			this.async.stop()
+		}
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		// the entries still queued are dropped, see DroppedEntries
+		if this.async != nil {
+			this.async.abandon()
+		}
+		err = ctx.Err()
+	}
+	if errFlush := this.flushBuffers(); err == nil {
+		err = errFlush
+	}
+	for _, f := range this.files {
+		var errClose error
+		switch f := f.(type) {
+		case *Logger:
+			errClose = f.Shutdown(ctx)
+		case io.Closer:
+			errClose = f.Close()
+		}
+		if err == nil {
+			err = errClose
+		}
+	}
+	if errClose := this.closeOutputs(); err == nil {
+		err = errClose
+	}
+	return err
 }
 
-func (this *EasyLogger) Debug(a ...interface{}) {
-	pc := make([]uintptr, 10)
-	runtime.Callers(2, pc)
-	f := runtime.FuncForPC(pc[0])
-	file, line := f.FileLine(pc[0])
-	fileName := filepath.Base(file)
+// closeOutputs closes the EntrySinks, writers and routes added to the logger, the log files
+// aside, it returns the first error
+func (this *EasyLogger) closeOutputs() error {
+	var outputs []interface{}
+	if this.sinks != nil {
+		this.sinks.mu.RLock()
+		for _, s := range this.sinks.sinks {
+			if ls, ok := s.(levelSink); ok {
+				s = ls.EntrySink
+			}
+			outputs = append(outputs, s)
+		}
+		this.sinks.mu.RUnlock()
+	}
+	if this.out != nil {
+		this.out.mu.Lock()
+		if mw, ok := this.out.w.(*multiWriter); ok {
+			for _, w := range mw.writers {
+				if lw, ok := w.(*levelWriter); ok {
+					w = lw.Writer
+				}
+				outputs = append(outputs, w)
+			}
+		} else {
+			outputs = append(outputs, this.out.w)
+		}
+		this.out.mu.Unlock()
+	}
+	for _, r := range this.routes {
+		outputs = append(outputs, r.w)
+	}
+
+	var err error
+	closed := map[interface{}]bool{os.Stdout: true, os.Stderr: true}
+	for _, f := range this.files {
+		closed[f] = true
+	}
+	for _, b := range this.buffers {
+		closed[b] = true
+	}
+	for _, o := range outputs {
+		c, ok := o.(io.Closer)
+		if !ok {
+			continue
+		}
+		if reflect.TypeOf(o).Comparable() {
+			if closed[o] {
+				continue
+			}
+			closed[o] = true
+		}
+		if errClose := c.Close(); err == nil {
+			err = errClose
+		}
+	}
+	return err
+}
 
-	a = append([]interface{}{f.Name(), fileName + ":" + strconv.Itoa(line)}, a...)
-	this.output(Debug.Sprint(DEBUG), a...)
+// Close is Shutdown without deadline, it implements io.Closer.
+func (this *EasyLogger) Close() error {
+	return this.Shutdown(context.Background())
 }
 
-func (this *EasyLogger) Debugf(format string, a ...interface{}) {
-	pc := make([]uintptr, 10)
-	runtime.Callers(2, pc)
-	f := runtime.FuncForPC(pc[0])
-	file, line := f.FileLine(pc[0])
-	fileName := filepath.Base(file)
+// Trace logs at the TRACE level, the message starting with the function and the file:line of
+// the caller unless EnableCaller already adds them.
+func (this *EasyLogger) Trace(a ...interface{}) {
+	if !this.Enabled(TraceLevel) {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		funcName := strings.TrimPrefix(filepath.Ext(f.Function), ".")
+		a = append([]interface{}{funcName + "()", callerLocation(f)}, a...)
+	}
+	this.output(TraceLevel, a...)
+}
+
+func (this *EasyLogger) Tracef(format string, a ...interface{}) {
+	if !this.Enabled(TraceLevel) {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		funcName := strings.TrimPrefix(filepath.Ext(f.Function), ".")
+		a = append([]interface{}{funcName, callerLocation(f)}, a...)
+		format = "%s() %s " + format
+	}
+	this.outputf(TraceLevel, format, a...)
+}
 
-	a = append([]interface{}{f.Name(), fileName, line}, a...)
-	this.outputf(Debug.Sprint(DEBUG), "%s() %s:%d "+format, a...)
+// Debug logs at the DEBUG level, the message starting with the function and the file:line of
+// the caller unless EnableCaller already adds them.
+func (this *EasyLogger) Debug(a ...interface{}) {
+	if !this.Enabled(DebugLevel) {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		a = append([]interface{}{f.Function, callerLocation(f)}, a...)
+	}
+	this.output(DebugLevel, a...)
+}
+
+func (this *EasyLogger) Debugf(format string, a ...interface{}) {
+	if !this.Enabled(DebugLevel) {
+		return
+	}
+	if !this.callerOn {
+		f := this.callSite()
+		a = append([]interface{}{f.Function, callerLocation(f)}, a...)
+		format = "%s() %s " + format
+	}
+	this.outputf(DebugLevel, format, a...)
 }
 
 func (this *EasyLogger) Info(a ...interface{}) {
-	this.output(Info.Sprint(INFO), a...)
+	this.output(InfoLevel, a...)
 }
 
 func (this *EasyLogger) Infof(format string, a ...interface{}) {
-	this.outputf(Info.Sprint(INFO), format, a...)
+	this.outputf(InfoLevel, format, a...)
 }
 
 func (this *EasyLogger) Warn(a ...interface{}) {
-	this.output(Warn.Sprint(WARN), a...)
+	this.output(WarnLevel, a...)
 }
 
 func (this *EasyLogger) Warnf(format string, a ...interface{}) {
-	this.outputf(Warn.Sprint(WARN), format, a...)
+	this.outputf(WarnLevel, format, a...)
 }
 
 func (this *EasyLogger) Error(a ...interface{}) {
-	this.output(Error.Sprint(ERROR), a...)
+	this.output(ErrorLevel, a...)
 }
 
 func (this *EasyLogger) Errorf(format string, a ...interface{}) {
-	this.outputf(Error.Sprint(ERROR), format, a...)
+	this.outputf(ErrorLevel, format, a...)
 }
 
+// Fatal logs a FATAL entry, then exits the program with status 1, see SetExitFunc.
 func (this *EasyLogger) Fatal(a ...interface{}) {
-	this.output(Fatal.Sprint(FATAL), a...)
+	this.output(FatalLevel, a...)
+	this.exit(1)
 }
 
+// Fatalf logs a FATAL entry, then exits the program with status 1, see SetExitFunc.
 func (this *EasyLogger) Fatalf(format string, a ...interface{}) {
-	this.outputf(Fatal.Sprint(FATAL), format, a...)
+	this.outputf(FatalLevel, format, a...)
+	this.exit(1)
+}
+
+// Panic logs a PANIC entry, then panics with the message.
+func (this *EasyLogger) Panic(a ...interface{}) {
+	this.output(PanicLevel, a...)
+	panic(strings.TrimSuffix(fmt.Sprintln(a...), "\n"))
+}
+
+// Panicf logs a PANIC entry, then panics with the message.
+func (this *EasyLogger) Panicf(format string, a ...interface{}) {
+	this.outputf(PanicLevel, format, a...)
+	panic(fmt.Sprintf(format, a...))
+}
+
+// SetExitFunc sets the function called by Fatal and Fatalf to exit, e.g. to keep the tests
+// running, nil restores os.Exit. It should be called before the logger is used.
+func (this *EasyLogger) SetExitFunc(f func(code int)) {
+	this.exitFunc = f
+}
+
+// WithExitFunc sets the function called by Fatal and Fatalf to exit.
+func WithExitFunc(f func(code int)) Option {
+	return func(l *EasyLogger) {
+		l.SetExitFunc(f)
+	}
+}
+
+// exit writes the queued entries and exits with code
+func (this *EasyLogger) exit(code int) {
+	this.Flush()
+	if this.exitFunc != nil {
+		this.exitFunc(code)
+		return
+	}
+	os.Exit(code)
 }
@@ -1,8 +1,17 @@
-package EasyLogger
+package easylogger
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewSizeRotatingEasyLogger(t *testing.T) {
@@ -16,6 +25,7 @@ func TestNewSizeRotatingEasyLogger(t *testing.T) {
 		log.Ldate | log.Lmicroseconds,
 		"",
 		true)
+	l.SetExitFunc(func(int) {})
 
 	for i:= 0; i<10000;i++ {
 		l.Trace("hello world")
@@ -44,6 +54,7 @@ func TestNewTimeRotatingEasyLogger(t *testing.T) {
 		log.Ldate | log.Lmicroseconds,
 		"",
 		true)
+	l.SetExitFunc(func(int) {})
 
 	l.Trace("hello world")
 	l.Debug("hello world")
@@ -59,3 +70,128 @@ func TestNewTimeRotatingEasyLogger(t *testing.T) {
 	l.Errorf("f:%s", "hello world")
 	l.Fatalf("f:%s", "hello world")
 }
+
+func TestNewTimeRotatingDualFormatEasyLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewTimeRotatingDualFormatEasyLogger(
+		dir,
+		1,
+		1,
+		true,
+		false,
+		log.Ldate|log.Lmicroseconds,
+		"",
+		false)
+
+	l.Info("hello", "world")
+	l.Errorf("f:%s", "hello world")
+
+	name := time.Now().Format(FileNameTimeFormat)
+	text, err := ioutil.ReadFile(filepath.Join(dir, name+FileNameExt))
+	assert.Nil(t, err)
+	assert.Contains(t, string(text), "hello world")
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, name+JSONFileNameExt))
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.Equal(t, "INFO", m["level"])
+	assert.Equal(t, "hello world", m["msg"])
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &m))
+	assert.Equal(t, "ERROR", m["level"])
+	assert.Equal(t, "f:hello world", m["msg"])
+}
+
+func TestEasyLogger_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewTimeRotatingDualFormatEasyLogger(dir, 1, 0, true, false, 0, "", false)
+	l.Info("first")
+	assert.Nil(t, l.Rotate())
+	l.Info("second")
+
+	name := time.Now().Format(FileNameTimeFormat)
+	for _, ext := range []string{FileNameExt, JSONFileNameExt} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name+ext))
+		assert.Nil(t, err)
+		assert.Contains(t, string(b), "first")
+		b, err = ioutil.ReadFile(filepath.Join(dir, name+".1"+ext))
+		assert.Nil(t, err)
+		assert.Contains(t, string(b), "second")
+	}
+}
+
+func TestEasyLogger_FatalExits(t *testing.T) {
+	var buf bytes.Buffer
+	var codes []int
+	l := &EasyLogger{out: newSink(&buf)}
+	l.EnableAsync(AsyncConfig{})
+	l.SetExitFunc(func(code int) {
+		// the entry is written before exiting
+		assert.Contains(t, buf.String(), "fatal 2")
+		codes = append(codes, code)
+	})
+	l.Fatalf("fatal %d", 2)
+	// exits even if FATAL entries are not logged
+	l.SetLevel(PanicLevel)
+	l.Fatal("fatal")
+	assert.Equal(t, []int{1, 1}, codes)
+}
+
+func TestEasyLogger_Panic(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	assert.PanicsWithValue(t, "boom 42", func() { l.Panic("boom", 42) })
+	assert.PanicsWithValue(t, "boom 43", func() { l.Panicf("boom %d", 43) })
+	assert.Contains(t, buf.String(), PANIC)
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
+
+func TestEasyLogger_Shutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir), WithDualFormat(true), WithCompress(true))
+	l.EnableAsync(AsyncConfig{})
+	l.Info("bye")
+	assert.Nil(t, l.Shutdown(context.Background()))
+
+	name := time.Now().UTC().Format(FileNameTimeFormat)
+	for _, ext := range []string{FileNameExt, JSONFileNameExt} {
+		_, err = os.Stat(filepath.Join(dir, name+ext+CompressSuffix))
+		assert.Nil(t, err)
+	}
+}
+
+func TestEasyLogger_Close(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir), WithBuffer(4096, time.Hour))
+	l.EnableAsync(AsyncConfig{})
+	for i := 0; i < 100; i++ {
+		l.Info("draining")
+	}
+	assert.Nil(t, l.Close())
+	assert.True(t, l.async.isStopped())
+	tl := l.files[0].(*Logger)
+	assert.Nil(t, tl.millCh)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt))
+	assert.Nil(t, err)
+	assert.Equal(t, 100, strings.Count(string(b), "draining"))
+
+	// a second Close and a late entry don't block
+	l.Warn("late")
+	assert.Nil(t, l.Close())
+}
@@ -0,0 +1,50 @@
+// +build echo
+
+package easylogger
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+// EchoLogger returns an echo middleware logging the requests like HTTPMiddleware, in place of
+// middleware.Logger. Build with -tags echo:
+//
+//	e := echo.New()
+//	e.Use(el.EchoLogger(), el.EchoRecovery())
+//
+// The errors of the handlers are handled by the HTTPErrorHandler of echo before the request is
+// logged, so that its status is the status answered.
+func (this *EasyLogger) EchoLogger() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			id, r := withRequestID(c.Response().Header(), c.Request())
+			c.SetRequest(r)
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			res := c.Response()
+			this.logRequest(c.Request(), c.RealIP(), res.Status, res.Size, id, start)
+			return nil
+		}
+	}
+}
+
+// EchoRecovery returns an echo middleware recovering from the panics of the handlers, in place
+// of middleware.Recover: the panic is logged at the ERROR level with its stack trace, and
+// returned as an error to the HTTPErrorHandler of echo.
+func (this *EasyLogger) EchoRecovery() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					this.logPanic(c.Request(), v)
+					err = fmt.Errorf("panic: %v", v)
+				}
+			}()
+			return next(c)
+		}
+	}
+}
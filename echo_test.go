@@ -0,0 +1,45 @@
+// +build echo
+
+package easylogger
+
+import (
+	"bytes"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEasyLogger_EchoLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	e := echo.New()
+	e.Use(l.EchoLogger(), l.EchoRecovery())
+	e.GET("/orders", func(c echo.Context) error {
+		return c.String(http.StatusOK, RequestIDFromContext(c.Request().Context()))
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, "req-1", rec.Body.String())
+	assert.Contains(t, buf.String(), "GET /orders 200 bytes=5")
+	assert.Contains(t, buf.String(), "request_id=req-1")
+
+	buf.Reset()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+	assert.Contains(t, buf.String(), "GET /missing 404")
+
+	buf.Reset()
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, buf.String(), "panic serving GET /panic: boom")
+	assert.Contains(t, buf.String(), "\tgithub.com/joeqian10/EasyLogger/v2.TestEasyLogger_EchoLogger.func2()")
+	assert.Contains(t, buf.String(), "GET /panic 500")
+}
@@ -0,0 +1,137 @@
+package easylogger
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// EncryptedPrefix marks the encrypted values in JSON outputs
+const EncryptedPrefix = "enc:v1:"
+
+// FieldEncrypter encrypts the values of sensitive fields in JSON outputs with AES-GCM,
+// leaving the rest of the entry readable.
+type FieldEncrypter struct {
+	fields        map[string]bool
+	deterministic bool
+	aead          cipher.AEAD
+	nonceKey      []byte
+}
+
+// NewFieldEncrypter creates a FieldEncrypter for the given JSON keys. key must be at least 16 bytes.
+// If deterministic is true, the same value of the same field is always encrypted to the same
+// ciphertext so that encrypted values can still be joined or grouped on, at the cost of
+// revealing which entries share a value.
+func NewFieldEncrypter(key []byte, deterministic bool, fields ...string) (*FieldEncrypter, error) {
+	if len(key) < 16 {
+		return nil, errors.New("encryption key must be at least 16 bytes")
+	}
+	block, err := aes.NewCipher(deriveKey(key, "easylogger field encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	e := &FieldEncrypter{
+		fields:        make(map[string]bool),
+		deterministic: deterministic,
+		aead:          aead,
+		nonceKey:      deriveKey(key, "easylogger field nonce"),
+	}
+	for _, f := range fields {
+		e.fields[f] = true
+	}
+	return e, nil
+}
+
+func deriveKey(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+// Sensitive reports whether the field is encrypted.
+func (e *FieldEncrypter) Sensitive(field string) bool {
+	return e.fields[field]
+}
+
+// Encrypt encrypts the value of a field, the field name is authenticated as well
+// so that a value can't be moved to another field unnoticed.
+func (e *FieldEncrypter) Encrypt(field string, plaintext []byte) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if e.deterministic {
+		mac := hmac.New(sha256.New, e.nonceKey)
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := e.aead.Seal(nonce, nonce, plaintext, []byte(field))
+	return EncryptedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt.
+func (e *FieldEncrypter) Decrypt(field string, value string) ([]byte, error) {
+	if !strings.HasPrefix(value, EncryptedPrefix) {
+		return nil, errors.New("value is not encrypted")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(value[len(EncryptedPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < e.aead.NonceSize() {
+		return nil, errors.New("encrypted value is too short")
+	}
+	nonce := sealed[:e.aead.NonceSize()]
+	return e.aead.Open(nil, nonce, sealed[len(nonce):], []byte(field))
+}
+
+// encryptJSON replaces the values of the sensitive keys of a JSON object by their ciphertext,
+// the plaintext being the raw JSON of the value. The rest of b is kept as is, in its order.
+func (e *FieldEncrypter) encryptJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if t, err := dec.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	out := make([]byte, 0, len(b)+64)
+	last := 0
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var v json.RawMessage
+		if err = dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		k, _ := t.(string)
+		if !e.fields[k] {
+			continue
+		}
+		s, err := e.Encrypt(k, v)
+		if err != nil {
+			return nil, err
+		}
+		// v ends where the decoder stands
+		end := int(dec.InputOffset())
+		out = append(out, b[last:end-len(v)]...)
+		q, _ := json.Marshal(s)
+		out = append(out, q...)
+		last = end
+	}
+	return append(out, b[last:]...), nil
+}
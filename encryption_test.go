@@ -0,0 +1,92 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestFieldEncrypter(t *testing.T) {
+	_, err := NewFieldEncrypter([]byte("short"), false, "msg")
+	assert.NotNil(t, err)
+
+	e, err := NewFieldEncrypter(testKey, false, "msg")
+	assert.Nil(t, err)
+	assert.True(t, e.Sensitive("msg"))
+	assert.False(t, e.Sensitive("level"))
+
+	a, err := e.Encrypt("msg", []byte("secret"))
+	assert.Nil(t, err)
+	b, err := e.Encrypt("msg", []byte("secret"))
+	assert.Nil(t, err)
+	assert.NotEqual(t, a, b)
+	assert.True(t, strings.HasPrefix(a, EncryptedPrefix))
+
+	p, err := e.Decrypt("msg", a)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(p))
+
+	// the field name is authenticated
+	_, err = e.Decrypt("other", a)
+	assert.NotNil(t, err)
+}
+
+func TestFieldEncrypter_Deterministic(t *testing.T) {
+	e, err := NewFieldEncrypter(testKey, true, "msg")
+	assert.Nil(t, err)
+
+	a, _ := e.Encrypt("msg", []byte("secret"))
+	b, _ := e.Encrypt("msg", []byte("secret"))
+	c, _ := e.Encrypt("msg", []byte("other secret"))
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestEasyLogger_SetFieldEncrypter(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&bytes.Buffer{}), jsonOut: &buf}
+	e, _ := NewFieldEncrypter(testKey, false, "msg")
+	l.SetFieldEncrypter(e)
+
+	l.Info("card", "4111111111111111")
+	assert.NotContains(t, buf.String(), "4111111111111111")
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "INFO", m["level"])
+	p, err := e.Decrypt("msg", m["msg"].(string))
+	assert.Nil(t, err)
+	assert.Equal(t, `"card 4111111111111111"`, string(p))
+}
+
+func TestFieldEncrypter_KeepsOrder(t *testing.T) {
+	e, _ := NewFieldEncrypter(testKey, false, "card", "msg")
+	b, err := e.encryptJSON([]byte(`{"time":"now","level":"INFO","msg":"paid","zip":"75001","card":{"number":"4111"},"amount":12.5}`))
+	assert.Nil(t, err)
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	var keys []string
+	dec.Token()
+	for dec.More() {
+		k, _ := dec.Token()
+		keys = append(keys, k.(string))
+		var v json.RawMessage
+		assert.Nil(t, dec.Decode(&v))
+	}
+	assert.Equal(t, []string{"time", "level", "msg", "zip", "card", "amount"}, keys)
+	assert.True(t, strings.HasPrefix(string(b), `{"time":"now","level":"INFO","msg":"enc:v1:`))
+	assert.True(t, strings.HasSuffix(string(b), `,"amount":12.5}`))
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(b, &m))
+	p, err := e.Decrypt("card", m["card"].(string))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"number":"4111"}`, string(p))
+
+	_, err = e.encryptJSON([]byte(`["msg"]`))
+	assert.NotNil(t, err)
+}
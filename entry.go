@@ -0,0 +1,126 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Entry is a single log record produced by the level methods before it is
+// rendered into the text and/or JSON outputs.
+type Entry struct {
+	Time     time.Time
+	Level    Level
+	GID      uint64
+	Caller   string // file:line of the call site, only set for JSON outputs, with EnableCaller or the log flags showing the file
+	Function string // function of the call site, only set with EnableCaller
+	Message  string
+	Stack    string // stack trace of the call site, only set with EnableStackTrace and ErrorWithStack
+	Fields   Fields
+	Labels   []string // for routing, not rendered
+}
+
+// Fields are the key/value pairs attached to an entry
+type Fields map[string]interface{}
+
+// reservedKeys are the keys of the JSON entries which fields can't override,
+// such fields are written with a "fields." prefix instead
+var reservedKeys = map[string]bool{"time": true, "level": true, "gid": true, "caller": true, "func": true, "msg": true, "stack": true}
+
+// jsonEntry is the wire layout of the fixed part of an Entry in JSON outputs
+type jsonEntry struct {
+	Time     string `json:"time"`
+	Level    string `json:"level"`
+	GID      uint64 `json:"gid,omitempty"`
+	Caller   string `json:"caller,omitempty"`
+	Function string `json:"func,omitempty"`
+	Message  string `json:"msg"`
+	Stack    string `json:"stack,omitempty"`
+}
+
+// MarshalJSON encodes the entry as a single JSON object without a trailing newline,
+// the fields being top-level keys following the fixed ones.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(jsonEntry{
+		Time:     e.Time.Format(time.RFC3339Nano),
+		Level:    e.Level.String(),
+		GID:      e.GID,
+		Caller:   e.Caller,
+		Function: e.Function,
+		Message:  e.Message,
+		Stack:    e.Stack,
+	})
+	if err != nil || len(e.Fields) == 0 {
+		return b, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, k := range e.Fields.keys() {
+		v, err := json.Marshal(jsonValue(e.Fields[k]))
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(e.Fields[k]))
+		}
+		key := k
+		if reservedKeys[k] {
+			key = "fields." + k
+		}
+		kb, _ := json.Marshal(key)
+		buf.WriteByte(',')
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue makes the values which would lose their meaning in JSON readable, e.g. errors.
+// fmt.Sprint calls Error or String, printing "<nil>" for the nil pointers instead of panicking.
+func jsonValue(v interface{}) interface{} {
+	switch v.(type) {
+	case error, fmt.Stringer:
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+// keys returns the keys of the fields, sorted
+func (f Fields) keys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String renders the fields as space separated key=value pairs sorted by key,
+// the values with spaces, quotes, '=' or control characters being quoted.
+func (f Fields) String() string {
+	var sb strings.Builder
+	for i, k := range f.keys() {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(textValue(f[k]))
+	}
+	return sb.String()
+}
+
+func textValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.IndexFunc(s, func(c rune) bool {
+		return c == ' ' || c == '=' || c == '"' || unicode.IsControl(c)
+	}) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
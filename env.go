@@ -0,0 +1,103 @@
+package easylogger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the names of the environment variables read by LoadOptionsFromEnv
+const EnvPrefix = "EASYLOGGER_"
+
+// LoadOptionsFromEnv returns the options set by the environment variables, to override the
+// options compiled in, e.g. in containers:
+//
+//	opts, err := easylogger.LoadOptionsFromEnv()
+//	l := easylogger.NewEasyLogger(append([]easylogger.Option{easylogger.WithDirectory("./Logs/")}, opts...)...)
+//
+// The variables are named after the keys of Config: EASYLOGGER_LEVEL, EASYLOGGER_FORMAT,
+// EASYLOGGER_FILE, EASYLOGGER_DIR, EASYLOGGER_MAX_SIZE, EASYLOGGER_MAX_AGE,
+// EASYLOGGER_MAX_BACKUPS, EASYLOGGER_ROTATE_DAYS, EASYLOGGER_LOCAL_TIME, EASYLOGGER_COMPRESS,
+// EASYLOGGER_SHARED_FILES, EASYLOGGER_DUAL_FORMAT, EASYLOGGER_ERROR_FILE, EASYLOGGER_CONSOLE, EASYLOGGER_CONSOLE_LEVEL,
+// EASYLOGGER_PREFIX and EASYLOGGER_FLAGS, a comma separated list. EASYLOGGER_JSON=true is a shortcut for EASYLOGGER_FORMAT=json, ignored if EASYLOGGER_FORMAT is set. The variables
+// which are not set leave the options unchanged.
+func LoadOptionsFromEnv() ([]Option, error) {
+	var opts []Option
+	var errs []string
+	env := func(name string, parse func(v string) (Option, error)) {
+		v, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			return
+		}
+		opt, err := parse(strings.TrimSpace(v))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s%s: %s", EnvPrefix, name, err))
+			return
+		}
+		opts = append(opts, opt)
+	}
+	str := func(f func(string) Option) func(string) (Option, error) {
+		return func(v string) (Option, error) { return f(v), nil }
+	}
+	num := func(f func(int) Option) func(string) (Option, error) {
+		return func(v string) (Option, error) {
+			n, err := strconv.Atoi(v)
+			return f(n), err
+		}
+	}
+	flag := func(f func(bool) Option) func(string) (Option, error) {
+		return func(v string) (Option, error) {
+			b, err := strconv.ParseBool(v)
+			return f(b), err
+		}
+	}
+
+	env("LEVEL", func(v string) (Option, error) {
+		lvl, err := ParseLevel(v)
+		return WithLevel(lvl), err
+	})
+	env("FORMAT", func(v string) (Option, error) {
+		format, err := ParseFormat(v)
+		return WithFormat(format), err
+	})
+	if _, ok := os.LookupEnv(EnvPrefix + "FORMAT"); !ok {
+		// the shortcut doesn't override the format set explicitly
+		env("JSON", func(v string) (Option, error) {
+			json, err := strconv.ParseBool(v)
+			if json {
+				return WithFormat(JSONFormat), err
+			}
+			return WithFormat(TextFormat), err
+		})
+	}
+	env("FILE", str(WithFile))
+	env("DIR", str(WithDirectory))
+	env("MAX_SIZE", num(WithMaxSize))
+	env("MAX_AGE", num(WithMaxAge))
+	env("MAX_BACKUPS", num(WithMaxBackups))
+	env("ROTATE_DAYS", num(WithRotateDays))
+	env("LOCAL_TIME", flag(WithLocalTime))
+	env("COMPRESS", flag(WithCompress))
+	env("SHARED_FILES", flag(WithSharedFiles))
+	env("DUAL_FORMAT", flag(WithDualFormat))
+	env("ERROR_FILE", flag(WithErrorFile))
+	env("CONSOLE", flag(WithConsole))
+	env("CONSOLE_LEVEL", func(v string) (Option, error) {
+		lvl, err := ParseLevel(v)
+		return WithConsoleLevel(lvl), err
+	})
+	env("PREFIX", func(v string) (Option, error) {
+		// the prefix is not trimmed, it usually ends with a space
+		return WithPrefix(os.Getenv(EnvPrefix + "PREFIX")), nil
+	})
+	env("FLAGS", func(v string) (Option, error) {
+		flags, err := parseFlags(strings.Split(v, ","))
+		return WithFlags(flags), err
+	})
+
+	if len(errs) > 0 {
+		return nil, coded(CodeConfig, fmt.Errorf("invalid environment: %s", strings.Join(errs, "; ")))
+	}
+	return opts, nil
+}
@@ -0,0 +1,60 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestLoadOptionsFromEnv(t *testing.T) {
+	env := map[string]string{
+		"EASYLOGGER_LEVEL":       "error",
+		"EASYLOGGER_JSON":        "true",
+		"EASYLOGGER_MAX_BACKUPS": "7",
+		"EASYLOGGER_PREFIX":      "app ",
+		"EASYLOGGER_FLAGS":       "date, shortfile",
+	}
+	for k, v := range env {
+		assert.Nil(t, os.Setenv(k, v))
+		defer os.Unsetenv(k)
+	}
+
+	opts, err := LoadOptionsFromEnv()
+	assert.Nil(t, err)
+	// the environment overrides the options before it
+	l := NewEasyLogger(append([]Option{WithLevel(DebugLevel), WithMaxBackups(30), WithConsole(true)}, opts...)...)
+	assert.Equal(t, ErrorLevel, l.GetLevel())
+	assert.Equal(t, JSONFormat, l.GetFormat())
+	assert.Equal(t, "app ", l.prefix)
+	assert.Equal(t, log.Ldate|log.Lshortfile, l.flags)
+	assert.NotNil(t, l.console)
+
+	assert.Nil(t, os.Setenv("EASYLOGGER_MAX_AGE", "week"))
+	defer os.Unsetenv("EASYLOGGER_MAX_AGE")
+	assert.Nil(t, os.Setenv("EASYLOGGER_CONSOLE", "maybe"))
+	defer os.Unsetenv("EASYLOGGER_CONSOLE")
+	_, err = LoadOptionsFromEnv()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "EASYLOGGER_MAX_AGE")
+	assert.Contains(t, err.Error(), "EASYLOGGER_CONSOLE")
+}
+
+func TestLoadOptionsFromEnv_FormatOverJSON(t *testing.T) {
+	assert.Nil(t, os.Setenv("EASYLOGGER_FORMAT", "json"))
+	defer os.Unsetenv("EASYLOGGER_FORMAT")
+	assert.Nil(t, os.Setenv("EASYLOGGER_JSON", "false"))
+	defer os.Unsetenv("EASYLOGGER_JSON")
+
+	opts, err := LoadOptionsFromEnv()
+	assert.Nil(t, err)
+	l := NewEasyLogger(opts...)
+	assert.Equal(t, JSONFormat, l.GetFormat())
+
+	assert.Nil(t, os.Setenv("EASYLOGGER_FORMAT", "logfmt"))
+	assert.Nil(t, os.Setenv("EASYLOGGER_JSON", "true"))
+	opts, err = LoadOptionsFromEnv()
+	assert.Nil(t, err)
+	l = NewEasyLogger(opts...)
+	assert.Equal(t, LogfmtFormat, l.GetFormat())
+}
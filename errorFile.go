@@ -0,0 +1,31 @@
+package easylogger
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ErrorFileLevel is the minimum level of the entries written to the error files, see WithErrorFile
+const ErrorFileLevel = WarnLevel
+
+// WithErrorFile also writes the entries of ErrorFileLevel and the higher levels to a separate
+// error file, so that the errors can be tailed without the noise of the other entries: app.err.log
+// next to the app.log of WithFile, or a ".err.log" file next to each file of WithDirectory. The
+// error files rotate like the log files.
+func WithErrorFile(split bool) Option {
+	return withSetup(func(s *outputSetup) { s.errorFile = split })
+}
+
+// errorFileName returns the name of the error file of the size rotating log file fileName
+func errorFileName(fileName string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + ".err" + ext
+}
+
+// addErrorFile adds f to the log files and to the outputs, for the entries of ErrorFileLevel and
+// the higher levels
+func (this *EasyLogger) addErrorFile(f io.Writer, s *outputSetup) {
+	this.files = append(this.files, f)
+	this.out.add(&levelWriter{Writer: this.buffered(f, s), min: ErrorFileLevel})
+}
@@ -0,0 +1,50 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWithErrorFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithFile(filepath.Join(dir, "app.log")), WithErrorFile(true))
+	l.Info("info")
+	l.Warn("warn")
+	assert.Nil(t, l.Close())
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "app.log"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "info")
+	assert.Contains(t, string(b), "warn")
+	b, err = ioutil.ReadFile(filepath.Join(dir, "app.err.log"))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), "info")
+	assert.Contains(t, string(b), "warn")
+
+	l = NewEasyLogger(WithDirectory(dir), WithErrorFile(true))
+	l.Debug("debug")
+	l.Error("error")
+	assert.Nil(t, l.Close())
+
+	name := filepath.Join(dir, time.Now().Format(FileNameTimeFormat))
+	b, err = ioutil.ReadFile(name + FileNameExt)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "debug")
+	assert.Contains(t, string(b), "error")
+	b, err = ioutil.ReadFile(name + ErrorFileNameExt)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), "debug")
+	assert.Contains(t, string(b), "error")
+}
+
+func TestErrorFileName(t *testing.T) {
+	assert.Equal(t, "Logs/app.err.log", errorFileName("Logs/app.log"))
+	assert.Equal(t, "Logs.d/app.err", errorFileName("Logs.d/app"))
+}
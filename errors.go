@@ -0,0 +1,138 @@
+package easylogger
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrorCode identifies a class of internal failures of the loggers. The codes are stable, they
+// are written in the diagnostics and counted by GetErrorStats, so that the failures can be
+// aggregated and alerted on across services.
+type ErrorCode string
+
+const (
+	CodeWrite         ErrorCode = "EL_WRITE"          // an entry not written to the outputs
+	CodeRotation      ErrorCode = "EL_ROTATION"       // a log file not rotated
+	CodeCompression   ErrorCode = "EL_COMPRESSION"    // a rotated file not compressed
+	CodeRetention     ErrorCode = "EL_RETENTION"      // old files not removed
+	CodeSinkWrite     ErrorCode = "EL_SINK_WRITE"     // an entry not written to an EntrySink
+	CodeQueueOverflow ErrorCode = "EL_QUEUE_OVERFLOW" // an entry dropped because a queue or a buffer was full
+	CodeSpill         ErrorCode = "EL_SPILL"          // an entry not spilled or read back, see OverflowSpill
+	CodeConfig        ErrorCode = "EL_CONFIG"         // an invalid configuration
+	CodePolicy        ErrorCode = "EL_POLICY"         // an entry rejected by the FieldPolicy
+	CodeCapture       ErrorCode = "EL_CAPTURE"        // an entry not recorded by a CaptureWindow
+	CodeHook          ErrorCode = "EL_HOOK"           // a Hook failed
+)
+
+// errorCodes are all the codes, in the order of their declaration
+var errorCodes = []ErrorCode{CodeWrite, CodeRotation, CodeCompression, CodeRetention, CodeSinkWrite,
+	CodeQueueOverflow, CodeSpill, CodeConfig, CodePolicy, CodeCapture, CodeHook}
+
+var errorDescriptions = map[ErrorCode]string{
+	CodeWrite:         "write failed",
+	CodeRotation:      "rotation failed",
+	CodeCompression:   "compression failed",
+	CodeRetention:     "retention failed",
+	CodeSinkWrite:     "sink write failed",
+	CodeQueueOverflow: "queue overflow",
+	CodeSpill:         "spill failed",
+	CodeConfig:        "invalid configuration",
+	CodePolicy:        "entry rejected by policy",
+	CodeCapture:       "capture failed",
+	CodeHook:          "hook failed",
+}
+
+// LoggerError is an internal failure of a logger, e.g.
+//
+//	if errors.Is(err, easylogger.ErrRotation) {
+//
+// or, to get its code whatever the failure,
+//
+//	code := easylogger.ErrorCodeOf(err)
+type LoggerError struct {
+	Code ErrorCode
+	Err  error // the cause, nil for the sentinel errors
+}
+
+// the sentinel errors of the codes, a *LoggerError is one of them if it has the same code
+var (
+	ErrWrite         = &LoggerError{Code: CodeWrite}
+	ErrRotation      = &LoggerError{Code: CodeRotation}
+	ErrCompression   = &LoggerError{Code: CodeCompression}
+	ErrRetention     = &LoggerError{Code: CodeRetention}
+	ErrSinkWrite     = &LoggerError{Code: CodeSinkWrite}
+	ErrQueueOverflow = &LoggerError{Code: CodeQueueOverflow}
+	ErrSpill         = &LoggerError{Code: CodeSpill}
+	ErrConfig        = &LoggerError{Code: CodeConfig}
+	ErrPolicy        = &LoggerError{Code: CodePolicy}
+	ErrCapture       = &LoggerError{Code: CodeCapture}
+	ErrHook          = &LoggerError{Code: CodeHook}
+)
+
+func (e *LoggerError) Error() string {
+	if e.Err == nil {
+		return string(e.Code) + ": " + errorDescriptions[e.Code]
+	}
+	return string(e.Code) + ": " + e.Err.Error()
+}
+
+func (e *LoggerError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the errors of a code match its sentinel error.
+func (e *LoggerError) Is(target error) bool {
+	t, ok := target.(*LoggerError)
+	return ok && t.Err == nil && t.Code == e.Code
+}
+
+// ErrorCodeOf returns the code of the first *LoggerError in the chain of err, "" if there is none.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *LoggerError
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+// coded returns err as a *LoggerError of code, unless it already has a code
+func coded(code ErrorCode, err error) error {
+	if err == nil || ErrorCodeOf(err) != "" {
+		return err
+	}
+	return &LoggerError{Code: code, Err: err}
+}
+
+// codeOf returns the code of err, or code if it has none
+func codeOf(err error, code ErrorCode) ErrorCode {
+	if c := ErrorCodeOf(err); c != "" {
+		return c
+	}
+	return code
+}
+
+// errorCounts are the counters of the codes, accessed atomically
+var errorCounts = func() map[ErrorCode]*uint64 {
+	m := make(map[ErrorCode]*uint64, len(errorCodes))
+	for _, code := range errorCodes {
+		m[code] = new(uint64)
+	}
+	return m
+}()
+
+// countError counts a failure of code for GetErrorStats
+func countError(code ErrorCode) {
+	if n := errorCounts[code]; n != nil {
+		atomic.AddUint64(n, 1)
+	}
+}
+
+// GetErrorStats returns the number of internal failures of all the loggers by code, with all
+// the codes, e.g. to export them as metrics.
+func GetErrorStats() map[ErrorCode]uint64 {
+	stats := make(map[ErrorCode]uint64, len(errorCodes))
+	for code, n := range errorCounts {
+		stats[code] = atomic.LoadUint64(n)
+	}
+	return stats
+}
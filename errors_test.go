@@ -0,0 +1,43 @@
+package easylogger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestLoggerError(t *testing.T) {
+	err := coded(CodeRotation, os.ErrPermission)
+	assert.Equal(t, "EL_ROTATION: permission denied", err.Error())
+	assert.True(t, errors.Is(err, ErrRotation))
+	assert.False(t, errors.Is(err, ErrCompression))
+	assert.True(t, errors.Is(err, os.ErrPermission))
+	assert.Equal(t, CodeRotation, ErrorCodeOf(fmt.Errorf("rotating: %w", err)))
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(os.ErrPermission))
+	assert.Equal(t, "EL_CONFIG: invalid configuration", ErrConfig.Error())
+
+	// a code is kept once set
+	assert.Equal(t, err, coded(CodeWrite, err))
+	assert.True(t, errors.Is(ErrSinkBufferFull, ErrQueueOverflow))
+	assert.Equal(t, CodeQueueOverflow, codeOf(ErrSinkBufferFull, CodeSinkWrite))
+	assert.Equal(t, CodeSinkWrite, codeOf(os.ErrClosed, CodeSinkWrite))
+}
+
+func TestGetErrorStats(t *testing.T) {
+	before := GetErrorStats()
+	assert.Len(t, before, len(errorCodes))
+
+	l := &EasyLogger{out: newSink(newMultiWriter(failingWriter{}))}
+	l.Info("lost")
+	l.AddSink(&entriesSink{err: ErrSinkBufferFull})
+	l.Info("lost again")
+	_, err := (&Config{Flags: []string{"nope"}}).Options()
+	assert.True(t, errors.Is(err, ErrConfig))
+
+	after := GetErrorStats()
+	assert.Equal(t, before[CodeWrite]+2, after[CodeWrite])
+	assert.Equal(t, before[CodeQueueOverflow]+1, after[CodeQueueOverflow])
+	assert.Equal(t, before[CodeRotation], after[CodeRotation])
+}
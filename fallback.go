@@ -0,0 +1,89 @@
+package easylogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// FallbackPrefix marks the lines mirrored to stderr while all outputs are failing
+	FallbackPrefix = "[EasyLogger fallback]"
+
+	// at most fallbackBurst entries are mirrored to stderr per fallbackInterval
+	fallbackBurst    = 10
+	fallbackInterval = time.Second
+)
+
+var (
+	stderr io.Writer = os.Stderr
+
+	fallback = &stderrMirror{}
+)
+
+// multiWriter duplicates its writes to all the writers like io.MultiWriter,
+// but keeps going when one of them fails and only reports an error when
+// every writer has failed.
+type multiWriter struct {
+	writers []io.Writer
+}
+
+func newMultiWriter(writers ...io.Writer) *multiWriter {
+	return &multiWriter{writers: writers}
+}
+
+func (t *multiWriter) Write(p []byte) (n int, err error) {
+	failed := 0
+	for _, w := range t.writers {
+		if _, e := w.Write(p); e != nil {
+			failed++
+			err = e
+		}
+	}
+	if failed > 0 && failed == len(t.writers) {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// stderrMirror writes entries to stderr at a bounded rate
+type stderrMirror struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+	suppressed  int
+}
+
+// mirrorToStderr mirrors an ERROR or FATAL entry to stderr, it is used when the
+// entry could not be written to any of the outputs.
+func mirrorToStderr(level Level, gid uint64, msg string) {
+	if level < ErrorLevel {
+		return
+	}
+	fallback.write(fmt.Sprintf("%s %s %s GID %d, %s\n",
+		FallbackPrefix, time.Now().Format("2006/01/02 15:04:05.000000"), level.tag(), gid, msg))
+}
+
+func (m *stderrMirror) write(line string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.windowStart) >= fallbackInterval {
+		if m.suppressed > 0 {
+			fmt.Fprintf(stderr, "%s %d entries suppressed\n", FallbackPrefix, m.suppressed)
+			diagf(CodeWrite, "%d entries not mirrored to stderr", m.suppressed)
+		}
+		m.windowStart = now
+		m.count = 0
+		m.suppressed = 0
+	}
+	if m.count >= fallbackBurst {
+		m.suppressed++
+		return
+	}
+	m.count++
+	io.WriteString(stderr, line)
+}
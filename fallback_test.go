@@ -0,0 +1,55 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk is gone")
+}
+
+func TestMultiWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newMultiWriter(failingWriter{}, &buf)
+	n, err := w.Write([]byte("hello"))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "hello", buf.String())
+
+	w = newMultiWriter(failingWriter{}, failingWriter{})
+	_, err = w.Write([]byte("hello"))
+	assert.NotNil(t, err)
+}
+
+func TestMirrorToStderr(t *testing.T) {
+	var buf bytes.Buffer
+	stderr = &buf
+	fallback = &stderrMirror{}
+	defer func() {
+		stderr = os.Stderr
+		fallback = &stderrMirror{}
+	}()
+
+	l := &EasyLogger{out: newSink(newMultiWriter(failingWriter{})), flags: log.Ldate}
+	l.Info("not mirrored")
+	for i := 0; i < fallbackBurst+5; i++ {
+		l.Error("mirrored")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, fallbackBurst, len(lines))
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, FallbackPrefix))
+		assert.Contains(t, line, "mirrored")
+		assert.NotContains(t, line, "not mirrored")
+	}
+	assert.Equal(t, 5, fallback.suppressed)
+}
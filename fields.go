@@ -0,0 +1,75 @@
+package easylogger
+
+import (
+	"fmt"
+)
+
+// badKey is the key of a value without key given to With
+const badKey = "!BADKEY"
+
+// LoggerNameField is the field of the name of the logger writing an entry, see Named
+const LoggerNameField = "logger"
+
+// WithFields returns a child logger adding fields to all its entries, on top of the fields of
+// this logger. The child shares the outputs and the configuration of this logger at the time
+// of the call, see Clone.
+func (this *EasyLogger) WithFields(fields Fields) *EasyLogger {
+	return this.Clone(withFields(fields))
+}
+
+// With is like WithFields with the fields given as alternating keys and values,
+// e.g. l.With("user", id).Info("login").
+func (this *EasyLogger) With(keysAndValues ...interface{}) *EasyLogger {
+	return this.WithFields(this.toFields(keysAndValues))
+}
+
+// Named returns a child logger tagging all its entries with the name of a component in the
+// LoggerNameField field, like WithFields. The names of nested children are joined with dots,
+// e.g. l.Named("db").Named("pool") logs logger=db.pool.
+func (this *EasyLogger) Named(name string) *EasyLogger {
+	if parent := this.Name(); parent != "" && name != "" {
+		name = parent + "." + name
+	}
+	return this.WithFields(Fields{LoggerNameField: name})
+}
+
+// Name returns the name of the logger given by Named, "" if it has none.
+func (this *EasyLogger) Name() string {
+	name, _ := this.fields[LoggerNameField].(string)
+	return name
+}
+
+// toFields turns alternating keys and values into Fields. A non-string key is converted
+// with fmt.Sprint and a value without key is recorded under "!BADKEY", both being
+// misuses in strict mode.
+func (this *EasyLogger) toFields(keysAndValues []interface{}) Fields {
+	fields := make(Fields, len(keysAndValues)/2)
+	if len(keysAndValues)%2 != 0 {
+		this.misuse("odd number of keys and values: %v", keysAndValues)
+		fields[badKey] = keysAndValues[len(keysAndValues)-1]
+		keysAndValues = keysAndValues[:len(keysAndValues)-1]
+	}
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			this.misuse("non-string key %v", keysAndValues[i])
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields[key] = keysAndValues[i+1]
+	}
+	return fields
+}
+
+// withFields merges fields into the fields of the logger
+func withFields(fields Fields) Option {
+	return func(l *EasyLogger) {
+		merged := make(Fields, len(l.fields)+len(fields))
+		for k, v := range l.fields {
+			merged[k] = v
+		}
+		for k, v := range fields {
+			merged[k] = v
+		}
+		l.fields = merged
+	}
+}
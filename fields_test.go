@@ -0,0 +1,74 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestEasyLogger_With(t *testing.T) {
+	var text, js bytes.Buffer
+	l := &EasyLogger{out: newSink(&text), jsonOut: &js}
+
+	child := l.With("user", 42, "name", "joe qian").WithFields(Fields{"err": errors.New("boom"), "msg": "shadowed"})
+	child.Info("login")
+	assert.Contains(t, text.String(), `login err=boom msg=shadowed name="joe qian" user=42`)
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(js.Bytes(), &m))
+	assert.Equal(t, "login", m["msg"])
+	assert.Equal(t, float64(42), m["user"])
+	assert.Equal(t, "joe qian", m["name"])
+	assert.Equal(t, "boom", m["err"])
+	assert.Equal(t, "shadowed", m["fields.msg"])
+
+	// the parent is not affected
+	text.Reset()
+	l.Info("plain")
+	assert.NotContains(t, text.String(), "user=")
+}
+
+func TestEasyLogger_With_Misuse(t *testing.T) {
+	l := &EasyLogger{out: newSink(&bytes.Buffer{})}
+	assert.Equal(t, Fields{"1": "one", badKey: "dangling"}, l.toFields([]interface{}{1, "one", "dangling"}))
+
+	l.SetStrict(true)
+	assert.Panics(t, func() { l.With("key") })
+	assert.Panics(t, func() { l.With(1, "one") })
+}
+
+func TestEasyLogger_Named(t *testing.T) {
+	var text, js bytes.Buffer
+	l := &EasyLogger{out: newSink(&text), jsonOut: &js}
+
+	pool := l.Named("db").Named("pool")
+	assert.Equal(t, "db.pool", pool.Name())
+	assert.Equal(t, "", l.Name())
+	pool.With("size", 4).Info("connected")
+	assert.Contains(t, text.String(), "connected logger=db.pool size=4")
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(js.Bytes(), &m))
+	assert.Equal(t, "db.pool", m[LoggerNameField])
+}
+
+func TestEasyLogger_WithNilPointers(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetFormat(JSONFormat)
+	c := l.With("u", (*url.URL)(nil), "err", (*os.PathError)(nil))
+	assert.NotPanics(t, func() { c.Info("x") })
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "<nil>", m["u"])
+	assert.Equal(t, "<nil>", m["err"])
+
+	e := Entry{Message: "x", Fields: Fields{"u": (*url.URL)(nil)}}
+	assert.NotPanics(t, func() { fluentMessage("t", e) })
+	assert.Equal(t, Fields{"u": "<nil>"}, spillFields(e.Fields))
+}
@@ -0,0 +1,104 @@
+package easylogger
+
+import (
+	"container/list"
+	"sync"
+)
+
+// openFiles tracks the files opened by all the Loggers
+var openFiles = &fileBudget{
+	lru:   list.New(),
+	elems: make(map[*Logger]*list.Element),
+}
+
+// SetMaxOpenFiles sets the maximum number of log files kept open by all the Loggers together,
+// zero or less means no limit, which is the default. When a Logger opens a file beyond the
+// limit, the file of the least recently written Logger is closed, to be reopened on its
+// next Write. This prevents running out of file descriptors when there are many Loggers.
+// The limit is soft: the file is closed asynchronously, right after the new one is opened.
+func SetMaxOpenFiles(max int) {
+	openFiles.mu.Lock()
+	openFiles.max = max
+	victims := openFiles.victims()
+	openFiles.mu.Unlock()
+
+	for _, v := range victims {
+		go v.evict()
+	}
+}
+
+// OpenFiles returns the number of log files currently open by the Loggers, and the limit
+// set by SetMaxOpenFiles.
+func OpenFiles() (open int, max int) {
+	openFiles.mu.Lock()
+	defer openFiles.mu.Unlock()
+	return openFiles.lru.Len(), openFiles.max
+}
+
+// fileBudget is an LRU of the Loggers having a file open.
+// Its methods are called with the Logger's lock held, so it must never lock a Logger itself.
+type fileBudget struct {
+	mu    sync.Mutex
+	max   int
+	lru   *list.List // of *Logger, the most recently written at the front
+	elems map[*Logger]*list.Element
+}
+
+// opened records that l opened its file, closing the least recently written files over the limit
+func (b *fileBudget) opened(l *Logger) {
+	b.mu.Lock()
+	if e, ok := b.elems[l]; ok {
+		b.lru.MoveToFront(e)
+	} else {
+		b.elems[l] = b.lru.PushFront(l)
+	}
+	victims := b.victims()
+	b.mu.Unlock()
+
+	for _, v := range victims {
+		go v.evict()
+	}
+}
+
+// touched records that l wrote to its file
+func (b *fileBudget) touched(l *Logger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.max <= 0 {
+		return
+	}
+	if e, ok := b.elems[l]; ok {
+		b.lru.MoveToFront(e)
+	}
+}
+
+// closed records that l closed its file
+func (b *fileBudget) closed(l *Logger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if e, ok := b.elems[l]; ok {
+		b.lru.Remove(e)
+		delete(b.elems, l)
+	}
+}
+
+// victims removes the least recently written Loggers over the limit from the LRU and returns them
+func (b *fileBudget) victims() []*Logger {
+	var victims []*Logger
+	for b.max > 0 && b.lru.Len() > b.max {
+		e := b.lru.Back()
+		l := b.lru.Remove(e).(*Logger)
+		delete(b.elems, l)
+		victims = append(victims, l)
+	}
+	return victims
+}
+
+// evict closes the file of a Logger picked by the fileBudget, it is reopened on the next Write
+func (l *Logger) evict() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.close()
+}
@@ -0,0 +1,52 @@
+package easylogger
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetMaxOpenFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	SetMaxOpenFiles(2)
+	defer SetMaxOpenFiles(0)
+
+	var loggers []*Logger
+	for i := 0; i < 5; i++ {
+		l := &Logger{Directory: filepath.Join(root, fmt.Sprint(i)), MaxDays: 1}
+		loggers = append(loggers, l)
+		defer l.Close()
+	}
+	for round := 0; round < 3; round++ {
+		for i, l := range loggers {
+			_, err := l.Write([]byte(fmt.Sprintf("logger %d round %d\n", i, round)))
+			assert.Nil(t, err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	open, max := OpenFiles()
+	for open > 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		open, _ = OpenFiles()
+	}
+	assert.Equal(t, 2, open)
+	assert.Equal(t, 2, max)
+
+	// the evicted Loggers reopened their files without losing anything
+	for i, l := range loggers {
+		assert.Nil(t, l.Close())
+		name := filepath.Join(l.Directory, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt)
+		b, err := ioutil.ReadFile(name)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, strings.Count(string(b), fmt.Sprintf("logger %d round", i)))
+	}
+}
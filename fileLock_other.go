@@ -0,0 +1,16 @@
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly,!windows
+
+package easylogger
+
+import (
+	"os"
+)
+
+// the lock file of Shared is not locked on the other systems
+func lockExclusive(_ *os.File) error {
+	return nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
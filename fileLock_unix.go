@@ -0,0 +1,22 @@
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package easylogger
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockExclusive waits for an exclusive advisory lock of f
+func lockExclusive(f *os.File) error {
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,126 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileInfo describes a log file listed by FilesHandler
+type FileInfo struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// logFileExts are the extensions of the files served by FilesHandler, compressed or not
+var logFileExts = []string{FileNameExt, JSONFileNameExt}
+
+// FilesHandler returns an http.Handler serving the log files, to fetch them without shell access:
+//
+//	GET /              lists the log files as a JSON array of FileInfo
+//	GET /<name>        serves a file, range requests are supported
+//	GET /<name>.gz?decompress=true
+//	                   serves a compressed file decompressed on the fly
+//
+// Every request goes through auth first, which must reject the unauthorized ones. A nil auth
+// rejects all the requests. Use http.StripPrefix to mount the handler under a path.
+func (this *EasyLogger) FilesHandler(auth func(http.Handler) http.Handler) http.Handler {
+	if auth == nil {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		})
+	}
+	return auth(http.HandlerFunc(this.serveFiles))
+}
+
+func (this *EasyLogger) serveFiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	files, err := this.logFiles()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name == "" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(files)
+		return
+	}
+
+	// only serve the listed files, which also rules out paths escaping the directory
+	var fi *FileInfo
+	for i := range files {
+		if files[i].Name == name {
+			fi = &files[i]
+			break
+		}
+	}
+	if fi == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(this.dir, fi.Name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	c := compressorOf(name)
+	if c != nil && r.URL.Query().Get("decompress") == "true" {
+		gz, err := c.NewReader(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer gz.Close()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if r.Method == http.MethodGet {
+			io.Copy(w, gz)
+		}
+		return
+	}
+
+	if c == nil {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	http.ServeContent(w, r, fi.Name, fi.ModTime, f)
+}
+
+// logFiles lists the log files in the log directory
+func (this *EasyLogger) logFiles() ([]FileInfo, error) {
+	infos, err := ioutil.ReadDir(this.dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []FileInfo{}
+	for _, info := range infos {
+		if info.IsDir() || !isLogFileName(info.Name()) {
+			continue
+		}
+		files = append(files, FileInfo{Name: info.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+func isLogFileName(name string) bool {
+	name = strings.TrimSuffix(name, compressedExt(name))
+	for _, ext := range logFileExts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
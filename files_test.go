@@ -0,0 +1,79 @@
+package easylogger
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEasyLogger_FilesHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "2021-09-01.log"), []byte("hello world\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0644))
+	gzf, err := os.Create(filepath.Join(dir, "2021-08-31.log.gz"))
+	assert.Nil(t, err)
+	gz := gzip.NewWriter(gzf)
+	gz.Write([]byte("old entries\n"))
+	gz.Close()
+	gzf.Close()
+
+	l := &EasyLogger{dir: dir}
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	h := l.FilesHandler(auth)
+
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("Authorization", "Bearer token")
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var files []FileInfo
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &files))
+	assert.Equal(t, 2, len(files))
+
+	w = get("/2021-09-01.log", nil)
+	assert.Equal(t, "hello world\n", w.Body.String())
+
+	w = get("/2021-09-01.log", http.Header{"Range": []string{"bytes=6-10"}})
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "world", w.Body.String())
+
+	w = get("/2021-08-31.log.gz?decompress=true", nil)
+	assert.Equal(t, "old entries\n", w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get("/secret.txt", nil).Code)
+	assert.Equal(t, http.StatusNotFound, get("/../2021-09-01.log", nil).Code)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	l.FilesHandler(nil).ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
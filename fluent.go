@@ -0,0 +1,195 @@
+package easylogger
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrSinkBufferFull is returned by the buffered sinks when an entry is dropped because their
+// buffer is full, e.g. while their server is down
+var ErrSinkBufferFull error = &LoggerError{Code: CodeQueueOverflow, Err: errors.New("sink buffer full, entry dropped")}
+
+// ErrSinkClosed is returned by the buffered sinks when an entry is written after their Close
+var ErrSinkClosed error = &LoggerError{Code: CodeSinkWrite, Err: errors.New("sink closed, entry dropped")}
+
+// sinkGate rejects the entries written to a buffered sink once it is closed, instead of
+// sending them on its closed channel
+type sinkGate struct {
+	mu     sync.RWMutex
+	closed bool
+}
+
+// send calls trySend unless the sink is closed, trySend reporting whether the entry is
+// buffered
+func (g *sinkGate) send(trySend func() bool) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.closed {
+		return ErrSinkClosed
+	}
+	if !trySend() {
+		return ErrSinkBufferFull
+	}
+	return nil
+}
+
+// close calls closeCh once, after the sends in progress
+func (g *sinkGate) close(closeCh func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.closed {
+		g.closed = true
+		closeCh()
+	}
+}
+
+// FluentConfig configures a FluentSink
+type FluentConfig struct {
+	// Address is the address of the Fluentd or Fluent Bit forward input, the default is
+	// "127.0.0.1:24224".
+	Address string
+
+	// Tag is the tag of the entries, the default is "easylogger".
+	Tag string
+
+	// BufferSize is the maximum number of entries waiting to be sent, the default is 1024.
+	BufferSize int
+
+	// Retry is how the sending of an entry is retried, reconnecting after each failure, the
+	// default is DefaultRetryPolicy.
+	Retry *RetryPolicy
+
+	// DialTimeout is the timeout of the connection, the default is 5 seconds.
+	DialTimeout time.Duration
+}
+
+// FluentSink is an EntrySink forwarding the entries to Fluentd or Fluent Bit with the forward
+// protocol, in the background, see NewFluentSink
+type FluentSink struct {
+	config  FluentConfig
+	ch      chan []byte
+	done    chan struct{}
+	conn    net.Conn // only used by the sending goroutine
+	dropped uint64   // accessed atomically
+	gate    sinkGate
+}
+
+// NewFluentSink returns a FluentSink sending the entries in the background, connecting again
+// when the connection fails. The entries are buffered meanwhile, then dropped once the buffer
+// is full.
+//
+//	l.AddSink(easylogger.NewFluentSink(easylogger.FluentConfig{Tag: "app.billing"}))
+func NewFluentSink(config FluentConfig) *FluentSink {
+	if config.Address == "" {
+		config.Address = "127.0.0.1:24224"
+	}
+	if config.Tag == "" {
+		config.Tag = "easylogger"
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1024
+	}
+	if config.Retry == nil {
+		config.Retry = &DefaultRetryPolicy
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+	s := &FluentSink{config: config, ch: make(chan []byte, config.BufferSize), done: make(chan struct{})}
+	go s.run()
+	return s
+}
+
+// WriteEntry implements EntrySink.
+func (s *FluentSink) WriteEntry(e Entry) error {
+	err := s.gate.send(func() bool {
+		select {
+		case s.ch <- fluentMessage(s.config.Tag, e):
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return err
+}
+
+// Dropped returns the number of entries dropped because the buffer was full or they could
+// not be sent.
+func (s *FluentSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close sends the buffered entries, then closes the connection.
+func (s *FluentSink) Close() error {
+	s.gate.close(func() { close(s.ch) })
+	<-s.done
+	return nil
+}
+
+// run sends the entries
+func (s *FluentSink) run() {
+	defer close(s.done)
+	for msg := range s.ch {
+		err := s.config.Retry.Do(context.Background(), func() error {
+			return s.send(msg)
+		})
+		if err != nil {
+			atomic.AddUint64(&s.dropped, 1)
+			diagf(CodeSinkWrite, "entry not forwarded to %s: %v", s.config.Address, err)
+		}
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// send sends msg, connecting if needed
+func (s *FluentSink) send(msg []byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout("tcp", s.config.Address, s.config.DialTimeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// fluentMessage encodes e as a forward protocol message: [tag, EventTime, record], the record
+// having the keys of the JSON entries
+func fluentMessage(tag string, e Entry) []byte {
+	b := make([]byte, 0, 128)
+	b = append(b, 0x93)
+	b = appendMsgpackString(b, tag)
+	// EventTime, the extension 0 of 8 bytes: seconds and nanoseconds
+	b = append(b, 0xd7, 0x00)
+	b = appendUint32(b, uint32(e.Time.Unix()))
+	b = appendUint32(b, uint32(e.Time.Nanosecond()))
+
+	record := make(map[string]interface{}, len(e.Fields)+4)
+	for k, v := range e.Fields {
+		if reservedKeys[k] {
+			k = "fields." + k
+		}
+		record[k] = v
+	}
+	record["level"] = e.Level.String()
+	record["gid"] = e.GID
+	record["msg"] = e.Message
+	if e.Caller != "" {
+		record["caller"] = e.Caller
+	}
+	return appendMsgpackMap(b, record)
+}
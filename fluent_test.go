@@ -0,0 +1,61 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFluentMessage(t *testing.T) {
+	e := Entry{Time: time.Unix(1, 2), Level: InfoLevel, GID: 3, Message: "m", Fields: Fields{"msg": "x"}}
+	assert.Equal(t, append([]byte{0x93, 0xa1, 't', 0xd7, 0x00, 0, 0, 0, 1, 0, 0, 0, 2, 0x84,
+		0xaa}, "fields.msg\xa1x\xa3gid\x03\xa5level\xa4INFO\xa3msg\xa1m"...), fluentMessage("t", e))
+}
+
+func TestFluentSink(t *testing.T) {
+	// the server is down at first
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	s := NewFluentSink(FluentConfig{Address: addr, Tag: "app", BufferSize: 2,
+		Retry: &RetryPolicy{MaxAttempts: 100, InitialBackoff: 10 * time.Millisecond}})
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	l.AddSink(s)
+	l.Info("first")
+	for len(s.ch) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	l.Info("second")
+	l.Info("third")
+	l.Info("fourth")
+	// the first one is being sent, two are buffered and the last one is dropped
+	assert.Equal(t, uint64(1), s.Dropped())
+
+	ln, err = net.Listen("tcp", addr)
+	assert.Nil(t, err)
+	defer ln.Close()
+	received := make(chan []byte)
+	go func() {
+		conn, err := ln.Accept()
+		assert.Nil(t, err)
+		b, _ := ioutil.ReadAll(conn)
+		received <- b
+	}()
+	assert.Nil(t, s.Close())
+
+	b := <-received
+	for _, msg := range []string{"first", "second", "third"} {
+		assert.True(t, bytes.Contains(b, append([]byte{0xa3, 'm', 's', 'g', byte(0xa0 | len(msg))}, msg...)), msg)
+	}
+	assert.False(t, bytes.Contains(b, []byte("fourth")))
+
+	// the late entries are dropped
+	assert.Equal(t, ErrSinkClosed, s.WriteEntry(Entry{Message: "late"}))
+	assert.Equal(t, uint64(2), s.Dropped())
+	assert.Nil(t, s.Close())
+}
@@ -0,0 +1,79 @@
+package easylogger
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Format is the layout of the lines written to the outputs
+type Format int32
+
+const (
+	// TextFormat is the default, human-readable layout:
+	// <prefix><date and time> [LEVEL] GID <gid>, <message> <key=value fields>
+	TextFormat Format = iota
+
+	// JSONFormat writes one JSON object per line, with the keys time, level, gid, caller, msg
+	// and the fields. The prefix and the flags of the logger are not used.
+	JSONFormat
+
+	// LogfmtFormat writes one logfmt line per entry, key=value pairs with the same keys as
+	// JSONFormat, for the log shippers parsing logfmt. The prefix and the flags of the logger
+	// are not used.
+	LogfmtFormat
+)
+
+var formatNames = [...]string{"text", "json", "logfmt"}
+
+// String returns the name of the format, e.g. "json".
+func (f Format) String() string {
+	if f < TextFormat || f > LogfmtFormat {
+		return fmt.Sprintf("Format(%d)", int32(f))
+	}
+	return formatNames[f]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
+// ParseFormat parses a format name, case-insensitively.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for i, n := range formatNames {
+		if n == name {
+			return Format(i), nil
+		}
+	}
+	return TextFormat, fmt.Errorf("unknown format %q", s)
+}
+
+// SetFormat sets the layout of the lines written to the outputs, it is safe to call at any time.
+// The JSON sidecar, if any, is always JSON.
+func (this *EasyLogger) SetFormat(format Format) {
+	atomic.StoreInt32(&this.lineFormat, int32(format))
+}
+
+// GetFormat returns the layout of the lines written to the outputs.
+func (this *EasyLogger) GetFormat() Format {
+	return Format(atomic.LoadInt32(&this.lineFormat))
+}
+
+// WithFormat sets the layout of the lines written to the outputs.
+func WithFormat(format Format) Option {
+	return func(l *EasyLogger) {
+		l.SetFormat(format)
+	}
+}
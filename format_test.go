@@ -0,0 +1,46 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("JSON")
+	assert.Nil(t, err)
+	assert.Equal(t, JSONFormat, f)
+	f, err = ParseFormat("text")
+	assert.Nil(t, err)
+	assert.Equal(t, TextFormat, f)
+	_, err = ParseFormat("xml")
+	assert.NotNil(t, err)
+}
+
+func TestEasyLogger_SetFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "prefix ", flags: log.Ldate}
+	l.SetFormat(JSONFormat)
+	assert.Equal(t, JSONFormat, l.GetFormat())
+
+	l.With("user", 42).Warnf("hello %s", "world")
+	line := buf.String()
+	assert.False(t, strings.HasPrefix(line, "prefix "))
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(line), &m))
+	assert.Equal(t, "WARN", m["level"])
+	assert.Equal(t, "hello world", m["msg"])
+	assert.Equal(t, float64(42), m["user"])
+	assert.NotNil(t, m["time"])
+	assert.NotNil(t, m["gid"])
+	assert.True(t, strings.HasPrefix(m["caller"].(string), "format_test.go:"))
+
+	buf.Reset()
+	l.SetFormat(TextFormat)
+	l.Info("hello world")
+	assert.True(t, strings.HasPrefix(buf.String(), "prefix "))
+}
@@ -0,0 +1,126 @@
+package easylogger
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultTemplateTimeLayout is the default layout of {time} in the templates of TemplateFormatter
+const DefaultTemplateTimeLayout = "2006/01/02 15:04:05.000000"
+
+// Formatter renders the entries as text lines in place of the default layout, see SetFormatter
+type Formatter interface {
+	// Format returns the text line of e, a newline is added if it doesn't end with one.
+	Format(e Entry) []byte
+}
+
+// templateFields are the placeholders of the templates and how they render an entry
+var templateFields = map[string]func(f *TemplateFormatter, e Entry) string{
+	"time":   func(f *TemplateFormatter, e Entry) string { return e.Time.Format(f.TimeLayout) },
+	"level":  func(f *TemplateFormatter, e Entry) string { return e.Level.String() },
+	"gid":    func(f *TemplateFormatter, e Entry) string { return strconv.FormatUint(e.GID, 10) },
+	"caller": func(f *TemplateFormatter, e Entry) string { return orUnknown(e.Caller) },
+	"func":   func(f *TemplateFormatter, e Entry) string { return orUnknown(e.Function) },
+	"msg":    func(f *TemplateFormatter, e Entry) string { return e.Message },
+	"fields": func(f *TemplateFormatter, e Entry) string { return e.Fields.String() },
+}
+
+// templatePart is a literal text, or a placeholder if render is set
+type templatePart struct {
+	literal string
+	render  func(f *TemplateFormatter, e Entry) string
+}
+
+// TemplateFormatter is a Formatter laying out the entries according to a template, see
+// NewTemplateFormatter
+type TemplateFormatter struct {
+	// TimeLayout is the layout of {time}, see time.Time.Format, the default is
+	// DefaultTemplateTimeLayout.
+	TimeLayout string
+
+	parts  []templatePart
+	caller bool
+}
+
+// NewTemplateFormatter returns a TemplateFormatter replacing the placeholders of template by
+// the parts of the entries: {time}, {level}, {gid}, {caller} (file:line), {func}, {msg} and
+// {fields}, e.g.
+//
+//	f, err := easylogger.NewTemplateFormatter("{time} | {level} | {caller} | {msg} {fields}")
+//
+// The caller is captured for {caller}, {func} requires EnableCaller. An unknown
+// placeholder is an error.
+func NewTemplateFormatter(template string) (*TemplateFormatter, error) {
+	f := &TemplateFormatter{TimeLayout: DefaultTemplateTimeLayout}
+	for template != "" {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			f.parts = append(f.parts, templatePart{literal: template})
+			break
+		}
+		end := strings.IndexByte(template[open:], '}')
+		if end < 0 {
+			return nil, coded(CodeConfig, errors.New("unclosed placeholder in template: "+template[open:]))
+		}
+		name := template[open+1 : open+end]
+		render, ok := templateFields[name]
+		if !ok {
+			return nil, coded(CodeConfig, errors.New("unknown placeholder in template: {"+name+"}"))
+		}
+		if open > 0 {
+			f.parts = append(f.parts, templatePart{literal: template[:open]})
+		}
+		f.parts = append(f.parts, templatePart{render: render})
+		f.caller = f.caller || name == "caller"
+		template = template[open+end+1:]
+	}
+	return f, nil
+}
+
+// Format implements Formatter.
+func (f *TemplateFormatter) Format(e Entry) []byte {
+	var b []byte
+	for _, p := range f.parts {
+		if p.render != nil {
+			b = append(b, p.render(f, e)...)
+		} else {
+			b = append(b, p.literal...)
+		}
+	}
+	return b
+}
+
+// NeedsCaller reports whether the template shows the caller, which is then captured.
+func (f *TemplateFormatter) NeedsCaller() bool {
+	return f.caller
+}
+
+// orUnknown returns s, or "???" if it is empty
+func orUnknown(s string) string {
+	if s == "" {
+		return "???"
+	}
+	return s
+}
+
+// SetFormatter makes the text outputs render the entries with f in place of the default
+// layout, the prefix and the flags of the logger, nil restores the default layout. The lines
+// are not colored, and the stack traces are indented below them. It should be called before
+// the logger is used.
+func (this *EasyLogger) SetFormatter(f Formatter) {
+	this.formatter = f
+}
+
+// WithFormatter renders the text lines with f, see SetFormatter.
+func WithFormatter(f Formatter) Option {
+	return func(l *EasyLogger) {
+		l.formatter = f
+	}
+}
+
+// formatterNeedsCaller reports whether the Formatter shows the caller of the entries
+func (this *EasyLogger) formatterNeedsCaller() bool {
+	f, ok := this.formatter.(interface{ NeedsCaller() bool })
+	return ok && f.NeedsCaller()
+}
@@ -0,0 +1,50 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTemplateFormatter(t *testing.T) {
+	f, err := NewTemplateFormatter("{time} | {level} | {caller} | {msg} {fields}")
+	assert.Nil(t, err)
+	assert.True(t, f.NeedsCaller())
+	f.TimeLayout = time.RFC3339
+	e := Entry{Time: time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC), Level: WarnLevel, Caller: "main.go:7",
+		Message: "slow", Fields: Fields{"ms": 250}}
+	assert.Equal(t, "2021-09-01T12:00:00Z | WARN | main.go:7 | slow ms=250", string(f.Format(e)))
+
+	f, err = NewTemplateFormatter("[{gid}] {func}: {msg}")
+	assert.Nil(t, err)
+	assert.False(t, f.NeedsCaller())
+	assert.Equal(t, "[3] ???: hi", string(f.Format(Entry{GID: 3, Message: "hi"})))
+
+	_, err = NewTemplateFormatter("{level} {message}")
+	assert.Equal(t, CodeConfig, ErrorCodeOf(err))
+	_, err = NewTemplateFormatter("{level")
+	assert.Equal(t, CodeConfig, ErrorCodeOf(err))
+}
+
+func TestEasyLogger_SetFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "app ", colorMode: int32(ColorAlways)}
+	f, err := NewTemplateFormatter("{level} {caller} {msg}")
+	assert.Nil(t, err)
+	l.SetFormatter(f)
+
+	l.Info("formatted")
+	l.Clone().ErrorWithStack(errors.New("failed"))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Regexp(t, `^INFO formatter_test.go:\d+ formatted$`, lines[0])
+	assert.Regexp(t, `^ERROR formatter_test.go:\d+ failed$`, lines[1])
+	assert.Equal(t, "\tgithub.com/joeqian10/EasyLogger/v2.TestEasyLogger_SetFormatter()", lines[2])
+
+	buf.Reset()
+	l.SetFormatter(nil)
+	l.Info("default")
+	assert.Contains(t, buf.String(), "app ")
+}
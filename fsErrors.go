@@ -0,0 +1,18 @@
+// +build !plan9
+
+package easylogger
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isStaleHandle reports whether err tells that the handle of a file on a network filesystem is stale
+func isStaleHandle(err error) bool {
+	return errors.Is(err, syscall.ESTALE)
+}
+
+// isTransientFSError reports whether err may be a transient failure of a network filesystem
+func isTransientFSError(err error) bool {
+	return errors.Is(err, syscall.ESTALE) || errors.Is(err, syscall.EIO)
+}
@@ -0,0 +1,10 @@
+package easylogger
+
+// the errors of Plan 9 are strings, the failures of the network filesystems are not told apart
+func isStaleHandle(_ error) bool {
+	return false
+}
+
+func isTransientFSError(_ error) bool {
+	return false
+}
@@ -0,0 +1,64 @@
+// +build !plan9
+
+package easylogger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLogger_NetworkFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer func() { writeFile = (*os.File).Write }()
+
+	var failures []error
+	var handles []*os.File
+	writeFile = func(f *os.File, p []byte) (int, error) {
+		handles = append(handles, f)
+		if len(failures) > 0 {
+			err := failures[0]
+			failures = failures[1:]
+			return 0, &os.PathError{Op: "write", Path: f.Name(), Err: err}
+		}
+		return f.Write(p)
+	}
+	var reported []error
+	l := &Logger{Directory: dir, MaxDays: 1, NetworkFS: true, OnError: func(err error) { reported = append(reported, err) },
+		NetworkRetry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}
+	defer l.Close()
+
+	// a stale handle is replaced
+	failures = []error{syscall.ESTALE, syscall.EIO}
+	n, err := l.Write([]byte("recovered\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, 3, len(handles))
+	assert.True(t, handles[0] != handles[1])
+	assert.True(t, handles[1] == handles[2])
+
+	// the persistent failures are reported
+	failures = []error{syscall.EIO, syscall.EIO, syscall.EIO}
+	_, err = l.Write([]byte("lost\n"))
+	assert.True(t, errors.Is(err, syscall.EIO))
+	assert.Equal(t, 1, len(reported))
+	assert.Equal(t, 0, len(failures))
+
+	// only the transient errors are retried
+	failures = []error{syscall.ENOSPC, syscall.EIO}
+	_, err = l.Write([]byte("full\n"))
+	assert.True(t, errors.Is(err, syscall.ENOSPC))
+	assert.Equal(t, 2, len(reported))
+	failures = nil
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt))
+	assert.Nil(t, err)
+	assert.Equal(t, "recovered\n", string(b))
+}
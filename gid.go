@@ -0,0 +1,32 @@
+package easylogger
+
+import (
+	"runtime"
+)
+
+// GetGID returns the id of the calling goroutine, read from the header of its stack trace.
+func GetGID() uint64 {
+	var buf [32]byte
+	// "goroutine 42 [running]:\n...", only the header fits in buf
+	b := buf[:runtime.Stack(buf[:], false)]
+	var n uint64
+	for i := len("goroutine "); i < len(b) && '0' <= b[i] && b[i] <= '9'; i++ {
+		n = n*10 + uint64(b[i]-'0')
+	}
+	return n
+}
+
+// EnableGID adds the id of the logging goroutine to the entries, the default. Reading it costs
+// a stack walk on every entry, high-throughput loggers can leave it out: the text lines then
+// have no "GID <gid>," and the JSON and logfmt lines no gid key. It should be called before
+// the logger is used.
+func (this *EasyLogger) EnableGID(enabled bool) {
+	this.noGID = !enabled
+}
+
+// WithGID adds the id of the logging goroutine to the entries or leaves it out, see EnableGID.
+func WithGID(enabled bool) Option {
+	return func(l *EasyLogger) {
+		l.EnableGID(enabled)
+	}
+}
@@ -0,0 +1,38 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetGID(t *testing.T) {
+	main := GetGID()
+	assert.NotEqual(t, uint64(0), main)
+	ch := make(chan uint64)
+	go func() { ch <- GetGID() }()
+	other := <-ch
+	assert.NotEqual(t, uint64(0), other)
+	assert.NotEqual(t, main, other)
+}
+
+func TestEasyLogger_EnableGID(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), colorMode: int32(ColorNever)}
+	l.Info("with gid")
+	assert.Contains(t, buf.String(), " GID ")
+
+	l.EnableGID(false)
+	buf.Reset()
+	l.With("k", "v").Info("without gid")
+	assert.Equal(t, "[INFO ] without gid k=v\n", buf.String())
+
+	buf.Reset()
+	l.SetFormat(JSONFormat)
+	l.Info("json")
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	_, ok := m["gid"]
+	assert.False(t, ok)
+}
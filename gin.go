@@ -0,0 +1,43 @@
+// +build gin
+
+package easylogger
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// GinLogger returns a gin middleware logging the requests like HTTPMiddleware, in place of
+// gin.Logger. Build with -tags gin:
+//
+//	r := gin.New()
+//	r.Use(el.GinLogger(), el.GinRecovery())
+func (this *EasyLogger) GinLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		var id string
+		id, c.Request = withRequestID(c.Writer.Header(), c.Request)
+		c.Next()
+		bytes := c.Writer.Size()
+		if bytes < 0 {
+			bytes = 0
+		}
+		this.logRequest(c.Request, c.ClientIP(), c.Writer.Status(), int64(bytes), id, start)
+	}
+}
+
+// GinRecovery returns a gin middleware recovering from the panics of the handlers, in place of
+// gin.Recovery: the panic is logged at the ERROR level with its stack trace, and the request
+// answered with the status 500.
+func (this *EasyLogger) GinRecovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if v := recover(); v != nil {
+				this.logPanic(c.Request, v)
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+// +build gin
+
+package easylogger
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEasyLogger_GinLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	r := gin.New()
+	r.Use(l.GinLogger(), l.GinRecovery())
+	var ctxID string
+	r.GET("/orders", func(c *gin.Context) {
+		ctxID = RequestIDFromContext(c.Request.Context())
+		c.String(http.StatusOK, "orders")
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/orders", nil))
+	assert.NotEqual(t, "", ctxID)
+	assert.Equal(t, ctxID, rec.Header().Get(RequestIDHeader))
+	assert.Contains(t, buf.String(), "GET /orders 200 bytes=6")
+	assert.Contains(t, buf.String(), "request_id="+ctxID)
+
+	buf.Reset()
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, buf.String(), "panic serving GET /panic: boom")
+	assert.Contains(t, buf.String(), "\tgithub.com/joeqian10/EasyLogger/v2.TestEasyLogger_GinLogger.func2()")
+	assert.Contains(t, buf.String(), "GET /panic 500 bytes=0")
+}
@@ -1,9 +1,19 @@
-module github.com/joeqian10/EasyLogger
+module github.com/joeqian10/EasyLogger/v2
 
 go 1.14
 
 require (
+	github.com/BurntSushi/toml v0.3.1
+	github.com/gin-gonic/gin v1.7.7
 	github.com/gookit/color v1.4.2
+	github.com/labstack/echo/v4 v4.6.3
 	github.com/natefinch/lumberjack v2.0.0+incompatible
-	github.com/stretchr/testify v1.6.1
+	github.com/prometheus/client_golang v1.11.0
+	github.com/sirupsen/logrus v1.8.1
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel/trace v1.0.0
+	go.uber.org/zap v1.21.0
+	google.golang.org/grpc v1.40.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
@@ -0,0 +1,80 @@
+// +build grpc
+
+package easylogger
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// GRPCUnaryServerInterceptor returns a grpc.UnaryServerInterceptor logging the RPCs once they
+// are served, with the fields grpc_method, grpc_code, duration_ms and peer, and the error if
+// any. Build with -tags grpc:
+//
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(el.GRPCUnaryServerInterceptor(nil)),
+//		grpc.StreamInterceptor(el.GRPCStreamServerInterceptor(nil)))
+//
+// The RPCs are logged at the INFO level, WARN for the errors of the client like NotFound and
+// ERROR for the others, e.g. Internal. levels overrides the level of the successful RPCs of
+// some methods, e.g. {"/grpc.health.v1.Health/Check": easylogger.DebugLevel}.
+func (this *EasyLogger) GRPCUnaryServerInterceptor(levels map[string]Level) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		this.logRPC(ctx, info.FullMethod, levels, err, start)
+		return resp, err
+	}
+}
+
+// GRPCStreamServerInterceptor returns a grpc.StreamServerInterceptor logging the streams once
+// they are closed, see GRPCUnaryServerInterceptor.
+func (this *EasyLogger) GRPCStreamServerInterceptor(levels map[string]Level) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		this.logRPC(ss.Context(), info.FullMethod, levels, err, start)
+		return err
+	}
+}
+
+// logRPC logs an RPC served
+func (this *EasyLogger) logRPC(ctx context.Context, method string, levels map[string]Level, err error, start time.Time) {
+	code := status.Code(err)
+	level := grpcLevel(code)
+	if l, ok := levels[method]; ok && code == codes.OK {
+		level = l
+	}
+	level, ok := this.ctxLevel(ctx, level)
+	if !ok {
+		return
+	}
+	fields := Fields{
+		"grpc_method": method,
+		"grpc_code":   code.String(),
+		"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields["peer"] = p.Addr.String()
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	this.ctxLogger(ctx).WithFields(fields).emitAt(level, method+" "+code.String(), 0)
+}
+
+// grpcLevel returns the level of the RPCs ending with code
+func grpcLevel(code codes.Code) Level {
+	switch code {
+	case codes.OK:
+		return InfoLevel
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return WarnLevel
+	}
+	return ErrorLevel
+}
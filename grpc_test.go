@@ -0,0 +1,75 @@
+// +build grpc
+
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestEasyLogger_GRPCUnaryServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetFormat(JSONFormat)
+	l.SetLevel(InfoLevel)
+	intercept := l.GRPCUnaryServerInterceptor(map[string]Level{"/grpc.health.v1.Health/Check": DebugLevel})
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	resp, err := intercept(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/shop.Orders/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "no such order")
+		})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "WARN", m["level"])
+	assert.Equal(t, "/shop.Orders/Get NotFound", m["msg"])
+	assert.Equal(t, "/shop.Orders/Get", m["grpc_method"])
+	assert.Equal(t, "NotFound", m["grpc_code"])
+	assert.Equal(t, "10.0.0.1:5000", m["peer"])
+	assert.Contains(t, m["error"], "no such order")
+	assert.NotNil(t, m["duration_ms"])
+
+	// the successful health checks are below the level of the logger, not their failures
+	buf.Reset()
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "resp", nil }
+	resp, err = intercept(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, ok)
+	assert.Equal(t, "resp", resp)
+	assert.Nil(t, err)
+	assert.Equal(t, "", buf.String())
+	intercept(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Internal, "down")
+		})
+	assert.Contains(t, buf.String(), `"level":"ERROR"`)
+}
+
+func TestEasyLogger_GRPCStreamServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	intercept := l.GRPCStreamServerInterceptor(nil)
+	err := intercept(nil, testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/shop.Orders/Watch"},
+		func(srv interface{}, stream grpc.ServerStream) error { return nil })
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "/shop.Orders/Watch OK")
+	assert.Contains(t, buf.String(), "grpc_code=OK")
+}
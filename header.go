@@ -0,0 +1,96 @@
+package easylogger
+
+import (
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// sink serializes the writes of a logger and its clones to their outputs, like log.Logger does
+type sink struct {
+	mu      sync.Mutex
+	w       io.Writer
+	min     Level // the lines of the lower levels are not written to the sink, see WithConsoleLevel
+	writing int32 // 1 while written in single writer mode, only used in strict mode, see SetSingleWriter
+	closed  int32 // 1 once the logger is shut down, only used in strict mode, see Shutdown
+}
+
+func newSink(w io.Writer) *sink {
+	return &sink{w: w}
+}
+
+func (s *sink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// appendHeader translates the log.Logger flags to the header of a text line, so that the
+// lines keep the exact prefix that log.Logger would write with the same prefix and flags.
+// file is the full path of the caller, "???" if unknown.
+func appendHeader(buf []byte, prefix string, flags int, t time.Time, file string, line int) []byte {
+	if flags&log.Lmsgprefix == 0 {
+		buf = append(buf, prefix...)
+	}
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		if flags&log.Ldate != 0 {
+			year, month, day := t.Date()
+			buf = appendInt(buf, year, 4)
+			buf = append(buf, '/')
+			buf = appendInt(buf, int(month), 2)
+			buf = append(buf, '/')
+			buf = appendInt(buf, day, 2)
+			buf = append(buf, ' ')
+		}
+		if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+			hour, min, sec := t.Clock()
+			buf = appendInt(buf, hour, 2)
+			buf = append(buf, ':')
+			buf = appendInt(buf, min, 2)
+			buf = append(buf, ':')
+			buf = appendInt(buf, sec, 2)
+			if flags&log.Lmicroseconds != 0 {
+				buf = append(buf, '.')
+				buf = appendInt(buf, t.Nanosecond()/1e3, 6)
+			}
+			buf = append(buf, ' ')
+		}
+	}
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if flags&log.Lshortfile != 0 {
+			for i := len(file) - 1; i > 0; i-- {
+				if file[i] == '/' {
+					file = file[i+1:]
+					break
+				}
+			}
+		}
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = appendInt(buf, line, -1)
+		buf = append(buf, ": "...)
+	}
+	if flags&log.Lmsgprefix != 0 {
+		buf = append(buf, prefix...)
+	}
+	return buf
+}
+
+// appendInt appends the decimal i zero-padded to wid digits, wid < 0 means no padding
+func appendInt(buf []byte, i int, wid int) []byte {
+	var b [20]byte
+	bp := len(b) - 1
+	for i >= 10 || wid > 1 {
+		wid--
+		q := i / 10
+		b[bp] = byte('0' + i - q*10)
+		bp--
+		i = q
+	}
+	b[bp] = byte('0' + i)
+	return append(buf, b[bp:]...)
+}
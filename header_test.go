@@ -0,0 +1,61 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAppendHeader_MatchesLogLogger(t *testing.T) {
+	combos := []int{
+		0,
+		log.Ldate,
+		log.Ltime,
+		log.Ldate | log.Ltime,
+		log.Ldate | log.Ltime | log.LUTC,
+		log.Lshortfile,
+		log.Llongfile,
+		log.Ldate | log.Lshortfile,
+		log.Ltime | log.Lshortfile | log.Lmsgprefix,
+		log.Ldate | log.Llongfile | log.Lmsgprefix,
+	}
+	for _, flags := range combos {
+		for {
+			var buf bytes.Buffer
+			ll := log.New(&buf, "prefix ", flags)
+			before := time.Now()
+			_, file, line, _ := runtime.Caller(0)
+			ll.Output(1, "message")
+			after := time.Now()
+
+			want := appendHeader(nil, "prefix ", flags, before, file, line+1)
+			if string(want) != string(appendHeader(nil, "prefix ", flags, after, file, line+1)) {
+				continue // the second changed, try again
+			}
+			assert.Equal(t, string(want)+"message\n", buf.String(), "flags "+strconv.Itoa(flags))
+			break
+		}
+	}
+}
+
+func TestAppendHeader_Microseconds(t *testing.T) {
+	tm := time.Date(2020, 3, 4, 5, 6, 7, 8009000, time.Local)
+	h := appendHeader(nil, "p ", log.Ldate|log.Lmicroseconds, tm, "/a/b/c.go", 12)
+	assert.Equal(t, "p 2020/03/04 05:06:07.008009 ", string(h))
+
+	h = appendHeader(nil, "p ", log.Lmicroseconds|log.Lshortfile|log.Lmsgprefix, tm, "/a/b/c.go", 12)
+	assert.Equal(t, "05:06:07.008009 c.go:12: p ", string(h))
+}
+
+func TestEasyLogger_LegacyFlags(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "app ", flags: log.Lshortfile}
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("hello")
+	assert.Regexp(t, `^app header_test.go:`+strconv.Itoa(line+1)+`: \S*\[INFO \]\S* GID \d+, hello\n$`, buf.String())
+}
@@ -0,0 +1,137 @@
+package easylogger
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMinDiskFree is the free disk space under which HealthCheck fails
+	DefaultMinDiskFree = 64 * 1024 * 1024
+
+	// DefaultMaxQueueFill is the share of the asynchronous queue above which HealthCheck fails
+	DefaultMaxQueueFill = 0.9
+)
+
+// errDiskFreeUnsupported is returned by diskFree on the platforms where it is not implemented
+var errDiskFreeUnsupported = errors.New("disk free space is not supported on this platform")
+
+// health tracks the outcome of the writes for HealthCheck
+type health struct {
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastErr     error
+
+	minDiskFree  uint64
+	maxSilence   time.Duration
+	maxQueueFill float64
+}
+
+func (h *health) succeeded() {
+	h.mu.Lock()
+	h.lastSuccess = time.Now()
+	h.lastErr = nil
+	h.mu.Unlock()
+}
+
+func (h *health) failed(err error) {
+	h.mu.Lock()
+	h.lastErr = err
+	h.mu.Unlock()
+}
+
+// SetHealthThresholds configures HealthCheck: it fails when the log directory has less than
+// minDiskFree bytes available (0 means DefaultMinDiskFree), or when nothing has been written
+// successfully for maxSilence (0 means no limit, as a quiet program is not unhealthy).
+func (this *EasyLogger) SetHealthThresholds(minDiskFree uint64, maxSilence time.Duration) {
+	this.health.mu.Lock()
+	defer this.health.mu.Unlock()
+
+	this.health.minDiskFree = minDiskFree
+	this.health.maxSilence = maxSilence
+}
+
+// SetHealthQueueThreshold makes HealthCheck fail when more than the share maxFill of the queue of
+// the asynchronous mode is used, e.g. 0.9 for 90% (0 means DefaultMaxQueueFill), as the
+// entries are then about to be dropped or to block the callers, see EnableAsync.
+func (this *EasyLogger) SetHealthQueueThreshold(maxFill float64) {
+	this.health.mu.Lock()
+	defer this.health.mu.Unlock()
+
+	this.health.maxQueueFill = maxFill
+}
+
+// HealthCheck verifies that the logger can do its job: the log directory is writable and has
+// enough free space, the open log files are still valid, the last write did not fail, and the
+// queue of the asynchronous mode is not nearly full.
+// It returns nil if everything is fine, otherwise an error describing all the problems found,
+// which makes it suitable for readiness and liveness probes.
+func (this *EasyLogger) HealthCheck() error {
+	var problems []string
+
+	if this.dir != "" {
+		if err := checkDirWritable(this.dir); err != nil {
+			problems = append(problems, err.Error())
+		}
+
+		this.health.mu.Lock()
+		minDiskFree := this.health.minDiskFree
+		this.health.mu.Unlock()
+		if minDiskFree == 0 {
+			minDiskFree = DefaultMinDiskFree
+		}
+		if free, err := diskFree(this.dir); err == nil && free < minDiskFree {
+			problems = append(problems, fmt.Sprintf("only %d bytes free in %s", free, this.dir))
+		}
+	}
+
+	for _, f := range this.files {
+		if l, ok := f.(*Logger); ok {
+			if err := l.checkFile(); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	this.health.mu.Lock()
+	lastSuccess, lastErr, maxSilence := this.health.lastSuccess, this.health.lastErr, this.health.maxSilence
+	this.health.mu.Unlock()
+	if lastErr != nil {
+		problems = append(problems, fmt.Sprintf("last write failed: %s", lastErr))
+	}
+	if maxSilence > 0 && !lastSuccess.IsZero() && time.Since(lastSuccess) > maxSilence {
+		problems = append(problems, fmt.Sprintf("no successful write for %s", time.Since(lastSuccess)))
+	}
+
+	if this.async != nil {
+		this.health.mu.Lock()
+		maxQueueFill := this.health.maxQueueFill
+		this.health.mu.Unlock()
+		if maxQueueFill == 0 {
+			maxQueueFill = DefaultMaxQueueFill
+		}
+		if queued := len(this.async.ch); float64(queued) > maxQueueFill*float64(cap(this.async.ch)) {
+			problems = append(problems, fmt.Sprintf("async queue nearly full: %d of %d entries", queued, cap(this.async.ch)))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("unhealthy logger: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkDirWritable creates and removes a temporary file in dir
+func checkDirWritable(dir string) error {
+	f, err := ioutil.TempFile(dir, ".easylogger-health-")
+	if err != nil {
+		return fmt.Errorf("log directory is not writable: %s", err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
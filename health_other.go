@@ -0,0 +1,7 @@
+// +build !linux,!darwin,!freebsd
+
+package easylogger
+
+func diskFree(_ string) (uint64, error) {
+	return 0, errDiskFreeUnsupported
+}
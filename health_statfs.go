@@ -0,0 +1,16 @@
+// +build linux darwin freebsd
+
+package easylogger
+
+import (
+	"syscall"
+)
+
+// diskFree returns the number of bytes available to unprivileged users in the file system of dir
+func diskFree(dir string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
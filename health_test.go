@@ -0,0 +1,59 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_HealthCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewTimeRotatingEasyLogger(dir, 1, 1, true, false, log.Ldate, "", false)
+	assert.Nil(t, l.HealthCheck())
+	l.Info("hello world")
+	assert.Nil(t, l.HealthCheck())
+
+	l.SetHealthThresholds(1<<62, 0)
+	if _, err := diskFree(dir); err != errDiskFreeUnsupported {
+		assert.NotNil(t, l.HealthCheck())
+	}
+
+	l.SetHealthThresholds(0, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	assert.NotNil(t, l.HealthCheck())
+}
+
+func TestEasyLogger_HealthCheck_FailedWrite(t *testing.T) {
+	l := &EasyLogger{out: newSink(newMultiWriter(failingWriter{}))}
+	assert.Nil(t, l.HealthCheck())
+	l.Info("hello world")
+	assert.NotNil(t, l.HealthCheck())
+}
+
+func TestEasyLogger_HealthCheck_AsyncQueue(t *testing.T) {
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	l.EnableAsync(AsyncConfig{QueueSize: 10})
+	defer func() {
+		close(w.gate)
+		l.Close()
+	}()
+
+	// the first entry blocks the writer, the others wait in the queue
+	for i := 0; i < 6; i++ {
+		l.Info("entry", i)
+	}
+	assert.Eventually(t, func() bool { return len(l.async.ch) == 5 }, time.Second, time.Millisecond)
+	assert.Nil(t, l.HealthCheck())
+
+	l.SetHealthQueueThreshold(0.4)
+	err := l.HealthCheck()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "async queue nearly full: 5 of 10 entries")
+}
@@ -0,0 +1,98 @@
+package easylogger
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Hook is called with the entries of the levels it is added for, see AddHook. It can forward
+// them, count them, or enrich them: the Fields of the entry are a copy, and the fields the hook
+// adds to them are logged with the entry.
+type Hook interface {
+	Fire(entry Entry) error
+}
+
+// HookFunc is a Hook calling the function
+type HookFunc func(entry Entry) error
+
+// Fire implements Hook.
+func (f HookFunc) Fire(entry Entry) error {
+	return f(entry)
+}
+
+// levelHooks holds the Hooks of a logger and its clones by level
+type levelHooks struct {
+	mu    sync.RWMutex
+	hooks [PanicLevel + 1][]Hook
+}
+
+// AddHook makes the hook stage call h with the entries of the given levels, all the levels if
+// none is given, in the order the hooks are added. The hooks are called synchronously by the
+// logging calls, before the entries are formatted, so they must be fast. A failing hook
+// doesn't prevent the entry from being logged. The logger and its clones share their hooks.
+func (this *EasyLogger) AddHook(h Hook, levels ...Level) {
+	if this.hooks == nil {
+		this.hooks = &levelHooks{}
+	}
+	if len(levels) == 0 {
+		levels = []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel, PanicLevel}
+	}
+	this.hooks.mu.Lock()
+	defer this.hooks.mu.Unlock()
+	for _, level := range levels {
+		if level < TraceLevel || level > PanicLevel {
+			this.misuse("invalid level %s", level)
+			continue
+		}
+		this.hooks.hooks[level] = append(this.hooks.hooks[level], h)
+	}
+}
+
+// RemoveHook removes h from all the levels, it returns false if h was not added. The hooks which
+// are not comparable, e.g. a HookFunc, can't be removed.
+func (this *EasyLogger) RemoveHook(h Hook) bool {
+	if this.hooks == nil || h == nil || !reflect.TypeOf(h).Comparable() {
+		return false
+	}
+	this.hooks.mu.Lock()
+	defer this.hooks.mu.Unlock()
+	removed := false
+	for level, hooks := range this.hooks.hooks {
+		for i, x := range hooks {
+			if x == h {
+				this.hooks.hooks[level] = append(hooks[:i:i], hooks[i+1:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	return removed
+}
+
+func (this *EasyLogger) hook(r *record) bool {
+	if this.hooks == nil || r.Level < TraceLevel || r.Level > PanicLevel || r.Level < this.GetLevel() {
+		// no hooks for the entries only recorded by a CaptureWindow
+		return true
+	}
+	this.hooks.mu.RLock()
+	defer this.hooks.mu.RUnlock()
+	hooks := this.hooks.hooks[r.Level]
+	if len(hooks) == 0 {
+		return true
+	}
+	fields := make(Fields, len(r.Fields))
+	for k, v := range r.Fields {
+		fields[k] = v
+	}
+	e := r.Entry
+	e.Fields = fields
+	for _, h := range hooks {
+		if err := h.Fire(e); err != nil {
+			diagf(CodeHook, "%s entry hook %T failed: %v", r.Level, h, err)
+		}
+	}
+	if len(fields) > 0 || len(r.Fields) > 0 {
+		r.Fields = fields
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// funcHook is a comparable HookFunc
+type funcHook struct {
+	HookFunc
+}
+
+func TestEasyLogger_AddHook(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), fields: Fields{"app": "billing"}}
+	var fired []string
+	counter := &funcHook{func(e Entry) error {
+		fired = append(fired, e.Level.String()+" "+e.Message)
+		return nil
+	}}
+	enricher := HookFunc(func(e Entry) error {
+		e.Fields["region"] = "eu"
+		return errors.New("failing hooks don't drop the entry")
+	})
+	l.AddHook(counter, WarnLevel, ErrorLevel)
+	l.Clone().AddHook(enricher, ErrorLevel)
+
+	l.Info("not fired")
+	l.Warn("warned")
+	l.Error("failed")
+	assert.Equal(t, []string{"WARN warned", "ERROR failed"}, fired)
+	assert.Contains(t, buf.String(), "warned app=billing\n")
+	assert.Contains(t, buf.String(), "failed app=billing region=eu\n")
+	// the fields of the logger are not modified
+	assert.Equal(t, Fields{"app": "billing"}, l.fields)
+
+	assert.False(t, l.RemoveHook(enricher))
+	assert.True(t, l.RemoveHook(counter))
+	assert.False(t, l.RemoveHook(counter))
+	l.Warn("removed")
+	assert.Len(t, fired, 2)
+
+	assert.Nil(t, l.SetStageEnabled(StageHook, false))
+	buf.Reset()
+	l.Error("disabled")
+	assert.Contains(t, buf.String(), "disabled app=billing\n")
+
+	l.SetStrict(true)
+	assert.Panics(t, func() { l.AddHook(counter, Level(42)) })
+}
@@ -0,0 +1,64 @@
+package easylogger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusError is a request rejected by the server of a sink
+type statusError struct {
+	url        string
+	status     int
+	body       string
+	retryAfter time.Duration // the Retry-After of the response, if any
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: %d %s", e.url, e.status, e.body)
+}
+
+// withStatusRetry returns a copy of p which doesn't retry the requests rejected with a client
+// error other than 429, as they would be rejected again
+func withStatusRetry(p RetryPolicy) *RetryPolicy {
+	retryable := p.Retryable
+	p.Retryable = func(err error) bool {
+		if se, ok := err.(*statusError); ok && se.status < 500 && se.status != http.StatusTooManyRequests {
+			return false
+		}
+		return retryable == nil || retryable(err)
+	}
+	return &p
+}
+
+// postJSON posts the JSON body to url with the header, a status other than 2xx is a *statusError
+func postJSON(client *http.Client, url string, header http.Header, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		se := &statusError{url: url, status: resp.StatusCode, body: strings.TrimSpace(string(msg))}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			se.retryAfter = time.Duration(seconds) * time.Second
+		}
+		return se
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
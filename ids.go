@@ -0,0 +1,146 @@
+package easylogger
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator generates the request and trace IDs, so that the IDs produced by EasyLogger match
+// the ones of the rest of the platform, see SetIDGenerator
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc is an IDGenerator calling the function
+type IDGeneratorFunc func() string
+
+// NewID implements IDGenerator.
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+var idGenerator atomic.Value // idGeneratorHolder, shared by all the loggers
+
+// idGeneratorHolder keeps the concrete type stored in idGenerator the same
+type idGeneratorHolder struct {
+	IDGenerator
+}
+
+// SetIDGenerator sets the IDGenerator of the process, the default is UUIDv7. nil restores the
+// default. It is safe to call at any time.
+func SetIDGenerator(g IDGenerator) {
+	if g == nil {
+		g = UUIDv7Generator{}
+	}
+	idGenerator.Store(idGeneratorHolder{g})
+}
+
+// NewID returns a new ID from the IDGenerator of the process.
+func NewID() string {
+	if h, ok := idGenerator.Load().(idGeneratorHolder); ok {
+		return h.NewID()
+	}
+	return UUIDv7Generator{}.NewID()
+}
+
+// UUIDv7Generator generates UUIDs version 7, time-ordered, e.g. "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+type UUIDv7Generator struct{}
+
+// NewID implements IDGenerator.
+func (UUIDv7Generator) NewID() string {
+	var u [16]byte
+	rand.Read(u[6:])
+	ms := uint64(currentTime().UnixNano() / int64(time.Millisecond))
+	u[0], u[1], u[2], u[3], u[4], u[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	u[6] = u[6]&0x0f | 0x70 // version 7
+	u[8] = u[8]&0x3f | 0x80 // variant 10
+
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// xidEncoding is the base32 encoding of the xids
+var xidEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
+
+// XIDGenerator generates xids, 20 characters sortable by time, e.g. "9m4e2mr0ui3e8a215n4g"
+type XIDGenerator struct{}
+
+var (
+	xidMachine [3]byte
+	xidCounter uint32 // accessed atomically
+)
+
+func init() {
+	host, _ := os.Hostname()
+	h := fnv.New32a()
+	h.Write([]byte(host))
+	copy(xidMachine[:], h.Sum(nil))
+	var b [4]byte
+	rand.Read(b[:])
+	xidCounter = binary.BigEndian.Uint32(b[:])
+}
+
+// NewID implements IDGenerator.
+func (XIDGenerator) NewID() string {
+	var id [12]byte
+	binary.BigEndian.PutUint32(id[0:4], uint32(currentTime().Unix()))
+	copy(id[4:7], xidMachine[:])
+	pid := os.Getpid()
+	id[7], id[8] = byte(pid>>8), byte(pid)
+	n := atomic.AddUint32(&xidCounter, 1)
+	id[9], id[10], id[11] = byte(n>>16), byte(n>>8), byte(n)
+	return xidEncoding.EncodeToString(id[:])
+}
+
+// snowflakeEpoch is the epoch of the snowflake IDs, in milliseconds since the Unix epoch
+const snowflakeEpoch = 1288834974657
+
+// SnowflakeGenerator generates snowflake IDs, 63-bit integers made of the milliseconds since
+// the snowflake epoch, the Node and a sequence number, in decimal
+type SnowflakeGenerator struct {
+	Node int64 // the number of the machine, in [0, 1023]
+
+	mu   sync.Mutex
+	last int64 // milliseconds of the last ID
+	seq  int64
+}
+
+// NewID implements IDGenerator.
+func (g *SnowflakeGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := currentTime().UnixNano()/int64(time.Millisecond) - snowflakeEpoch
+	if ms < g.last {
+		// the clock went back, keep the IDs increasing
+		ms = g.last
+	}
+	if ms == g.last {
+		g.seq = (g.seq + 1) & 0xfff
+		if g.seq == 0 {
+			// 4096 IDs in this millisecond already
+			ms++
+		}
+	} else {
+		g.seq = 0
+	}
+	g.last = ms
+	return strconv.FormatInt(ms<<22|(g.Node&0x3ff)<<12|g.seq, 10)
+}
@@ -0,0 +1,44 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestIDGenerators(t *testing.T) {
+	u := UUIDv7Generator{}.NewID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), u)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-v]{20}$`), XIDGenerator{}.NewID())
+
+	// unique, and sorted by time for the snowflakes
+	g := &SnowflakeGenerator{Node: 5}
+	for _, gen := range []IDGenerator{UUIDv7Generator{}, XIDGenerator{}, g} {
+		seen := make(map[string]bool)
+		var ids []string
+		for i := 0; i < 10000; i++ {
+			id := gen.NewID()
+			assert.False(t, seen[id])
+			seen[id] = true
+			ids = append(ids, id)
+		}
+		if gen == g {
+			assert.True(t, sort.StringsAreSorted(ids))
+		}
+	}
+	n, err := strconv.ParseInt(g.NewID(), 10, 64)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n>>12&0x3ff)
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	defer SetIDGenerator(nil)
+	assert.Equal(t, 36, len(NewID()))
+	SetIDGenerator(IDGeneratorFunc(func() string { return "req-1" }))
+	assert.Equal(t, "req-1", NewID())
+	SetIDGenerator(&SnowflakeGenerator{})
+	_, err := strconv.ParseInt(NewID(), 10, 64)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,120 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrJournaldUnavailable is returned by NewJournaldSink when not running under systemd
+var ErrJournaldUnavailable = errors.New("journald is not available")
+
+// JournaldSink is an EntrySink writing the entries to the systemd journal with its native
+// protocol, as structured entries with the fields PRIORITY, SYSLOG_IDENTIFIER, GID, CODE_FILE
+// and CODE_LINE when the caller is known, and the fields of the entry with their keys in upper
+// case, e.g. USER_ID for "user.id".
+type JournaldSink struct {
+	identifier string
+	mu         sync.Mutex
+	conn       net.Conn
+}
+
+// NewJournaldSink connects to journald, it returns ErrJournaldUnavailable when not running under
+// systemd, e.g. on other systems than Linux.
+func NewJournaldSink() (*JournaldSink, error) {
+	conn, err := dialJournald()
+	if err != nil {
+		return nil, err
+	}
+	return &JournaldSink{identifier: filepath.Base(os.Args[0]), conn: conn}, nil
+}
+
+// WithJournald adds a JournaldSink to the outputs when running under systemd, see AddSink.
+func WithJournald() Option {
+	return func(l *EasyLogger) {
+		s, err := NewJournaldSink()
+		if err != nil {
+			diagf(CodeConfig, "journald output not added: %v", err)
+			return
+		}
+		l.AddSink(s)
+	}
+}
+
+// WriteEntry implements EntrySink.
+func (s *JournaldSink) WriteEntry(e Entry) error {
+	msg := journaldMessage(e, s.identifier)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.conn.Write(msg)
+	return err
+}
+
+// Close closes the connection to journald.
+func (s *JournaldSink) Close() error {
+	return s.conn.Close()
+}
+
+// journaldMessage encodes e in the journald native protocol
+func journaldMessage(e Entry, identifier string) []byte {
+	var buf bytes.Buffer
+	severity := syslogSeverities[ErrorLevel]
+	if e.Level >= TraceLevel && e.Level <= PanicLevel {
+		severity = syslogSeverities[e.Level]
+	}
+	journaldField(&buf, "MESSAGE", e.Message)
+	journaldField(&buf, "PRIORITY", strconv.Itoa(severity))
+	journaldField(&buf, "SYSLOG_IDENTIFIER", identifier)
+	journaldField(&buf, "GID", strconv.FormatUint(e.GID, 10))
+	if i := strings.LastIndexByte(e.Caller, ':'); i > 0 {
+		journaldField(&buf, "CODE_FILE", e.Caller[:i])
+		journaldField(&buf, "CODE_LINE", e.Caller[i+1:])
+	}
+	for _, k := range e.Fields.keys() {
+		if name := journaldName(k); name != "" {
+			journaldField(&buf, name, textValue(jsonValue(e.Fields[k])))
+		}
+	}
+	return buf.Bytes()
+}
+
+// journaldField appends the field name=value, the values with new lines are prefixed by
+// their length instead
+func journaldField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if strings.IndexByte(value, '\n') < 0 {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldName makes key a valid journal field name: upper case letters, digits and
+// underscores, not starting with an underscore or a digit. It returns "" if nothing is left.
+func journaldName(key string) string {
+	name := strings.Map(func(c rune) rune {
+		switch {
+		case c >= 'a' && c <= 'z':
+			return c - 'a' + 'A'
+		case c >= 'A' && c <= 'Z' || c >= '0' && c <= '9':
+			return c
+		}
+		return '_'
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
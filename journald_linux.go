@@ -0,0 +1,16 @@
+package easylogger
+
+import (
+	"net"
+	"os"
+)
+
+// journaldSocket is the socket of the native protocol of journald, a variable so tests can mock it out
+var journaldSocket = "/run/systemd/journal/socket"
+
+func dialJournald() (net.Conn, error) {
+	if _, err := os.Stat(journaldSocket); err != nil {
+		return nil, ErrJournaldUnavailable
+	}
+	return net.Dial("unixgram", journaldSocket)
+}
@@ -0,0 +1,38 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewJournaldSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer func(socket string) { journaldSocket = socket }(journaldSocket)
+
+	journaldSocket = filepath.Join(dir, "missing")
+	_, err = NewJournaldSink()
+	assert.Equal(t, ErrJournaldUnavailable, err)
+	l := NewEasyLogger(WithJournald())
+	assert.Equal(t, 0, len(l.sinks.sinks))
+
+	journaldSocket = filepath.Join(dir, "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: journaldSocket, Net: "unixgram"})
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	l = NewEasyLogger(WithJournald())
+	assert.Equal(t, 1, len(l.sinks.sinks))
+	l.Error("hello")
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "MESSAGE=hello\nPRIORITY=3\n"))
+	assert.Nil(t, l.sinks.sinks[0].(*JournaldSink).Close())
+}
@@ -0,0 +1,9 @@
+// +build !linux
+
+package easylogger
+
+import "net"
+
+func dialJournald() (net.Conn, error) {
+	return nil, ErrJournaldUnavailable
+}
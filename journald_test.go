@@ -0,0 +1,14 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJournaldMessage(t *testing.T) {
+	e := Entry{Level: WarnLevel, GID: 7, Caller: "main.go:12", Message: "two\nlines",
+		Fields: Fields{"user.id": 42, "_private": "x", "9": "dropped"}}
+	assert.Equal(t, "MESSAGE\n\x09\x00\x00\x00\x00\x00\x00\x00two\nlines\n"+
+		"PRIORITY=4\nSYSLOG_IDENTIFIER=app\nGID=7\nCODE_FILE=main.go\nCODE_LINE=12\n"+
+		"PRIVATE=x\nUSER_ID=42\n", string(journaldMessage(e, "app")))
+}
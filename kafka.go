@@ -0,0 +1,195 @@
+package easylogger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// KafkaMessage is a message produced by a KafkaSink
+type KafkaMessage struct {
+	Topic string
+	Key   []byte // nil lets the producer choose the partition
+	Value []byte
+}
+
+// KafkaProducer sends messages to Kafka. The sink doesn't depend on a Kafka client, the
+// application adapts the client of its choice, e.g. with segmentio/kafka-go:
+//
+//	type kafkaGo struct{ w *kafka.Writer }
+//
+//	func (p kafkaGo) Produce(ctx context.Context, msgs []easylogger.KafkaMessage) error {
+//		kms := make([]kafka.Message, len(msgs))
+//		for i, m := range msgs {
+//			kms[i] = kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value}
+//		}
+//		return p.w.WriteMessages(ctx, kms...)
+//	}
+//
+//	func (p kafkaGo) Close() error { return p.w.Close() }
+//
+// and sets it as the Producer of the KafkaConfig.
+type KafkaProducer interface {
+	// Produce sends msgs, the messages having the same key must keep their order.
+	Produce(ctx context.Context, msgs []KafkaMessage) error
+	Close() error
+}
+
+// ErrNoKafkaProducer is returned by NewKafkaSink when the KafkaConfig has no Producer
+var ErrNoKafkaProducer = errors.New("no Kafka producer, see KafkaConfig.Producer")
+
+// KafkaPartitioner returns the key of the message of an entry, which decides its partition,
+// nil to let the producer choose
+type KafkaPartitioner func(e Entry) []byte
+
+// PartitionByLevel puts the entries of the same level in the same partition.
+func PartitionByLevel(e Entry) []byte {
+	return []byte(e.Level.String())
+}
+
+// PartitionByField puts the entries having the same value of the field key in the same
+// partition, e.g. PartitionByField("tenant"). The entries without the field have no key.
+func PartitionByField(key string) KafkaPartitioner {
+	return func(e Entry) []byte {
+		v, ok := e.Fields[key]
+		if !ok {
+			return nil
+		}
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+// KafkaConfig configures a KafkaSink
+type KafkaConfig struct {
+	// Producer sends the messages with the Kafka client of the application, it is closed
+	// with the sink.
+	Producer KafkaProducer
+
+	// Topic is the topic of the messages, the default is "logs".
+	Topic string
+
+	// Partitioner sets the keys of the messages, the default is no key.
+	Partitioner KafkaPartitioner
+
+	// BatchSize is the maximum number of messages produced at once, the default is 1000.
+	BatchSize int
+
+	// BatchWait is the maximum time an entry waits for its batch to fill, the default is
+	// 100 milliseconds.
+	BatchWait time.Duration
+
+	// BufferSize is the maximum number of entries waiting to be produced, the default is 10000.
+	BufferSize int
+
+	// Retry is how the producing of a batch is retried, the default is DefaultRetryPolicy.
+	Retry *RetryPolicy
+}
+
+// KafkaSink is an EntrySink producing the entries to Kafka as JSON messages, in batches, in
+// the background, see NewKafkaSink
+type KafkaSink struct {
+	config   KafkaConfig
+	producer KafkaProducer
+	ch       chan Entry
+	done     chan struct{}
+	dropped  uint64 // accessed atomically
+	gate     sinkGate
+}
+
+// NewKafkaSink returns a KafkaSink producing the entries in the background with the Producer
+// of config. The entries are buffered while Kafka is unreachable, then dropped once the
+// buffer is full. It is added next to the log files, which keep receiving the entries:
+//
+//	kafka, err := easylogger.NewKafkaSink(easylogger.KafkaConfig{Producer: kafkaGo{w},
+//		Topic: "billing-logs", Partitioner: easylogger.PartitionByLevel})
+//	if err == nil {
+//		l.AddSink(kafka)
+//		defer kafka.Close()
+//	}
+func NewKafkaSink(config KafkaConfig) (*KafkaSink, error) {
+	if config.Producer == nil {
+		return nil, ErrNoKafkaProducer
+	}
+
+	if config.Topic == "" {
+		config.Topic = "logs"
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	if config.BatchWait <= 0 {
+		config.BatchWait = 100 * time.Millisecond
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.Retry == nil {
+		config.Retry = &DefaultRetryPolicy
+	}
+	s := &KafkaSink{config: config, producer: config.Producer, ch: make(chan Entry, config.BufferSize), done: make(chan struct{})}
+	go s.run()
+	return s, nil
+}
+
+// WriteEntry implements EntrySink.
+func (s *KafkaSink) WriteEntry(e Entry) error {
+	err := s.gate.send(func() bool {
+		select {
+		case s.ch <- e:
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return err
+}
+
+// Dropped returns the number of entries dropped because the buffer was full or they could
+// not be produced.
+func (s *KafkaSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close produces the buffered entries, then closes the producer.
+func (s *KafkaSink) Close() error {
+	s.gate.close(func() { close(s.ch) })
+	<-s.done
+	return s.producer.Close()
+}
+
+// run produces the entries in batches
+func (s *KafkaSink) run() {
+	defer close(s.done)
+	runBatches(s.ch, s.config.BatchSize, s.config.BatchWait, s.produce)
+}
+
+// produce sends batch to Kafka
+func (s *KafkaSink) produce(batch []Entry) {
+	msgs := make([]KafkaMessage, 0, len(batch))
+	for _, e := range batch {
+		value, err := json.Marshal(e)
+		if err != nil {
+			atomic.AddUint64(&s.dropped, 1)
+			diagf(CodeSinkWrite, "entry not produced to %s: %v", s.config.Topic, err)
+			continue
+		}
+		msg := KafkaMessage{Topic: s.config.Topic, Value: value}
+		if s.config.Partitioner != nil {
+			msg.Key = s.config.Partitioner(e)
+		}
+		msgs = append(msgs, msg)
+	}
+	err := s.config.Retry.Do(context.Background(), func() error {
+		return s.producer.Produce(context.Background(), msgs)
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, uint64(len(msgs)))
+		diagf(CodeSinkWrite, "%d entries not produced to %s: %v", len(msgs), s.config.Topic, err)
+	}
+}
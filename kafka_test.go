@@ -0,0 +1,71 @@
+package easylogger
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeProducer struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]KafkaMessage
+	closed   bool
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, msgs []KafkaMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker not available")
+	}
+	p.batches = append(p.batches, append([]KafkaMessage(nil), msgs...))
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestKafkaSink(t *testing.T) {
+	_, err := NewKafkaSink(KafkaConfig{})
+	assert.Equal(t, ErrNoKafkaProducer, err)
+
+	p := &fakeProducer{failures: 1}
+	s, err := NewKafkaSink(KafkaConfig{Producer: p, Topic: "app",
+		Partitioner: PartitionByLevel, BatchSize: 2, BatchWait: time.Hour,
+		Retry: &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}})
+	assert.Nil(t, err)
+
+	var buf entriesSink
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	l.AddSink(s)
+	l.AddSink(&buf)
+	l.Info("first")
+	l.Warn("second")
+	l.Info("third")
+	assert.Nil(t, s.Close())
+	assert.True(t, p.closed)
+	assert.Equal(t, uint64(0), s.Dropped())
+	// the other outputs still receive the entries
+	assert.Len(t, buf.entries, 3)
+
+	assert.Len(t, p.batches, 2)
+	assert.Len(t, p.batches[0], 2)
+	assert.Equal(t, "app", p.batches[0][0].Topic)
+	assert.Equal(t, []byte("INFO"), p.batches[0][0].Key)
+	assert.Equal(t, []byte("WARN"), p.batches[0][1].Key)
+	assert.Contains(t, string(p.batches[0][1].Value), `"msg":"second"`)
+	assert.Contains(t, string(p.batches[1][0].Value), `"msg":"third"`)
+}
+
+func TestPartitionByField(t *testing.T) {
+	partition := PartitionByField("tenant")
+	assert.Equal(t, []byte("42"), partition(Entry{Fields: Fields{"tenant": 42}}))
+	assert.Nil(t, partition(Entry{}))
+}
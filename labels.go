@@ -0,0 +1,36 @@
+package easylogger
+
+import (
+	"io"
+)
+
+// route sends the entries carrying a label to a writer
+type route struct {
+	label string
+	w     io.Writer
+}
+
+// WithLabels returns a child logger attaching labels to all its entries, on top of the labels
+// of this logger. Unlike fields, labels are not rendered: they are meant for routing, see Route.
+func (this *EasyLogger) WithLabels(labels ...string) *EasyLogger {
+	return this.Clone(func(l *EasyLogger) {
+		l.labels = append(append([]string(nil), l.labels...), labels...)
+	})
+}
+
+// Route makes the entries carrying label be written to w as well, in the format of the logger,
+// e.g. to send the billing entries to a dedicated file. It applies to this logger and to the
+// children created afterwards, and should be called before the logger is used.
+func (this *EasyLogger) Route(label string, w io.Writer) {
+	this.routes = append(this.routes, route{label: label, w: w})
+}
+
+// HasLabel reports whether the entry carries label.
+func (e Entry) HasLabel(label string) bool {
+	for _, l := range e.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_Route(t *testing.T) {
+	var main, billing bytes.Buffer
+	l := &EasyLogger{out: newSink(&main)}
+	l.Route("billing", &billing)
+
+	var sampled []Entry
+	l.SetSampler(func(e Entry) bool {
+		sampled = append(sampled, e)
+		return true
+	})
+
+	b := l.WithLabels("billing")
+	b.Info("charged")
+	l.Info("unrelated")
+	b.WithLabels("eu").With("amount", 10).Info("refunded")
+
+	// labels are not rendered
+	assert.Equal(t, 3, strings.Count(main.String(), "\n"))
+	assert.NotContains(t, main.String(), "billing")
+	assert.Equal(t, 2, strings.Count(billing.String(), "\n"))
+	assert.Contains(t, billing.String(), "charged")
+	assert.Contains(t, billing.String(), "refunded amount=10")
+	assert.NotContains(t, billing.String(), "unrelated")
+
+	assert.True(t, sampled[2].HasLabel("billing"))
+	assert.True(t, sampled[2].HasLabel("eu"))
+	assert.False(t, sampled[1].HasLabel("billing"))
+}
@@ -0,0 +1,85 @@
+package easylogger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of an entry
+type Level int32
+
+const (
+	TraceLevel Level = iota
+	DebugLevel
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+	PanicLevel
+)
+
+var levelNames = [...]string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC"}
+var levelTags = [...]string{TRACE, DEBUG, INFO, WARN, ERROR, FATAL, PANIC}
+
+// String returns the name of the level, e.g. "INFO".
+func (l Level) String() string {
+	if l < TraceLevel || l > PanicLevel {
+		return fmt.Sprintf("Level(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// tag returns the tag of the level in the text lines, e.g. "[INFO ]"
+func (l Level) tag() string {
+	if l < TraceLevel || l > PanicLevel {
+		return "[" + l.String() + "]"
+	}
+	return levelTags[l]
+}
+
+// color returns the current LevelColor of the level
+func (l Level) color() LevelColor {
+	switch l {
+	case TraceLevel:
+		return Trace
+	case DebugLevel:
+		return Debug
+	case InfoLevel:
+		return Info
+	case WarnLevel:
+		return Warn
+	case ErrorLevel:
+		return Error
+	case FatalLevel:
+		return Fatal
+	case PanicLevel:
+		return Panic
+	}
+	return PlainColor{}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = lvl
+	return nil
+}
+
+// ParseLevel parses a level name, case-insensitively.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for i, n := range levelNames {
+		if n == name {
+			return Level(i), nil
+		}
+	}
+	return TraceLevel, fmt.Errorf("unknown level %q", s)
+}
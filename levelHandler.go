@@ -0,0 +1,62 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// levelPayload is the body of the requests and responses of LevelHandler
+type levelPayload struct {
+	Level *Level `json:"level,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// LevelHandler returns an http.Handler reporting and changing the level of the logger at run
+// time, e.g. to turn on the DEBUG entries of a service without restarting it:
+//
+//	GET                          answers {"level":"INFO"}
+//	PUT {"level":"debug"}        sets the level, and answers the new one
+//	PUT level=debug              the same as a form, e.g. curl -X PUT -d level=debug
+//
+// The handler doesn't check who calls it, serve it on an admin port or behind an authentication
+// middleware. Use http.StripPrefix to mount it under a path.
+func (this *EasyLogger) LevelHandler() http.Handler {
+	return http.HandlerFunc(this.serveLevel)
+}
+
+func (this *EasyLogger) serveLevel(w http.ResponseWriter, r *http.Request) {
+	reply := func(status int, p levelPayload) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(p)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var p levelPayload
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if v := r.FormValue("level"); v != "" {
+				p.Level = new(Level)
+				if err := p.Level.UnmarshalText([]byte(v)); err != nil {
+					reply(http.StatusBadRequest, levelPayload{Error: err.Error()})
+					return
+				}
+			}
+		} else if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			reply(http.StatusBadRequest, levelPayload{Error: "invalid request body: " + err.Error()})
+			return
+		}
+		if p.Level == nil {
+			reply(http.StatusBadRequest, levelPayload{Error: "missing level"})
+			return
+		}
+		this.SetLevel(*p.Level)
+	default:
+		reply(http.StatusMethodNotAllowed, levelPayload{Error: "only GET and PUT are supported"})
+		return
+	}
+	level := this.GetLevel()
+	reply(http.StatusOK, levelPayload{Level: &level})
+}
@@ -0,0 +1,73 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_LevelHandler(t *testing.T) {
+	l := &EasyLogger{}
+	l.SetLevel(InfoLevel)
+	h := l.LevelHandler()
+	serve := func(method, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("GET", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `{"level":"INFO"}`+"\n", rec.Body.String())
+
+	rec = serve("PUT", "application/json", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"level":"DEBUG"}`+"\n", rec.Body.String())
+	assert.Equal(t, DebugLevel, l.GetLevel())
+
+	rec = serve("PUT", "application/x-www-form-urlencoded", "level=warn")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, WarnLevel, l.GetLevel())
+
+	rec = serve("PUT", "application/json", `{"level":"verbose"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "verbose")
+	rec = serve("PUT", "application/x-www-form-urlencoded", "level=verbose")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve("PUT", "application/json", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"error":"missing level"}`+"\n", rec.Body.String())
+	rec = serve("POST", "", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, WarnLevel, l.GetLevel())
+}
+
+func TestEasyLogger_LevelHandlerClones(t *testing.T) {
+	l := &EasyLogger{}
+	l.SetLevel(InfoLevel)
+	child := l.With("component", "db")
+	grandchild := child.Clone()
+	own := l.Clone(WithLevel(ErrorLevel))
+
+	req := httptest.NewRequest("PUT", "/", strings.NewReader(`{"level":"debug"}`))
+	l.LevelHandler().ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, DebugLevel, child.GetLevel())
+	assert.Equal(t, DebugLevel, grandchild.GetLevel())
+	assert.True(t, child.Enabled(DebugLevel))
+	// the clones which set their own level keep it
+	assert.Equal(t, ErrorLevel, own.GetLevel())
+
+	child.SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, child.GetLevel())
+	assert.Equal(t, DebugLevel, l.GetLevel())
+	assert.Equal(t, DebugLevel, grandchild.GetLevel())
+	l.SetLevel(InfoLevel)
+	assert.Equal(t, WarnLevel, child.GetLevel())
+}
@@ -0,0 +1,53 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, lvl := range []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel, PanicLevel} {
+		parsed, err := ParseLevel(strings.ToLower(lvl.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, lvl, parsed)
+	}
+	_, err := ParseLevel("verbose")
+	assert.NotNil(t, err)
+
+	var lvl Level
+	assert.Nil(t, lvl.UnmarshalText([]byte("warn")))
+	assert.Equal(t, WarnLevel, lvl)
+	assert.Equal(t, "Level(42)", Level(42).String())
+}
+
+func TestEasyLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	assert.Equal(t, TraceLevel, l.GetLevel())
+
+	l.SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, l.GetLevel())
+	assert.False(t, l.Enabled(InfoLevel))
+	assert.True(t, l.Enabled(ErrorLevel))
+
+	l.Trace("trace")
+	l.Debugf("%s", "debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Errorf("%s", "error")
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "warn")
+	assert.Contains(t, buf.String(), "error")
+
+	// the level can be lowered again at runtime
+	buf.Reset()
+	l.SetLevel(DebugLevel)
+	l.Debug("debug")
+	assert.Contains(t, buf.String(), "debug")
+
+	c := l.Clone(WithLevel(ErrorLevel))
+	assert.Equal(t, ErrorLevel, c.GetLevel())
+	assert.Equal(t, DebugLevel, l.GetLevel())
+}
@@ -0,0 +1,116 @@
+package easylogger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// maxPendingLine is the length from which a line not ended yet is logged anyway, see LineWriter
+const maxPendingLine = 64 << 10
+
+// LineWriter is an io.Writer logging each line written to it as an entry, e.g. to pipe in the
+// output of a subprocess, see Writer and WriterLevel. It is safe for concurrent use.
+type LineWriter struct {
+	// ParseLevels logs the lines starting with a level tag like "[ERROR]" or "[warn]" at that
+	// level, without the tag, the other lines are logged at the level of the writer.
+	ParseLevels bool
+
+	el      *EasyLogger
+	level   Level
+	mu      sync.Mutex
+	pending []byte // the last line, not ended yet
+}
+
+// Writer returns a LineWriter logging the lines written to it at the INFO level, e.g.
+//
+//	stderr := l.WriterLevel(easylogger.WarnLevel)
+//	stderr.ParseLevels = true
+//	cmd.Stdout, cmd.Stderr = l.Writer(), stderr
+//
+// The last line is logged when it is ended, or by Flush or Close.
+func (this *EasyLogger) Writer() *LineWriter {
+	return this.WriterLevel(InfoLevel)
+}
+
+// WriterLevel returns a LineWriter logging the lines written to it at level, see Writer.
+func (this *EasyLogger) WriterLevel(level Level) *LineWriter {
+	return &LineWriter{el: this, level: level}
+}
+
+// Write logs the lines ended in p, it never fails.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.log(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+	if len(w.pending) >= maxPendingLine {
+		w.log(string(w.pending))
+		w.pending = nil
+	} else if len(w.pending) == 0 {
+		// drop the consumed lines
+		w.pending = nil
+	}
+	return len(p), nil
+}
+
+// Flush logs the last line, even if it is not ended.
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		w.log(string(w.pending))
+		w.pending = nil
+	}
+	return nil
+}
+
+// Close logs the last line like Flush, e.g. when the subprocess exited.
+func (w *LineWriter) Close() error {
+	return w.Flush()
+}
+
+// log logs a line, at the level of its tag if ParseLevels is set
+func (w *LineWriter) log(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" {
+		return
+	}
+	level := w.level
+	if w.ParseLevels {
+		level, line = parseLevelTag(level, line)
+	}
+	if !w.el.Enabled(level) {
+		return
+	}
+	w.el.emitAt(level, line, 0)
+}
+
+// parseLevelTag returns the level of the tag starting line, e.g. "[ERROR]", and the rest of
+// the line, or level and line if there is no tag
+func parseLevelTag(level Level, line string) (Level, string) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "[") {
+		return level, line
+	}
+	end := strings.IndexByte(trimmed, ']')
+	if end < 0 {
+		return level, line
+	}
+	name := trimmed[1:end]
+	if strings.EqualFold(strings.TrimSpace(name), "warning") {
+		name = "warn"
+	}
+	tagged, err := ParseLevel(name)
+	if err != nil {
+		return level, line
+	}
+	return tagged, strings.TrimLeft(trimmed[end+1:], " ")
+}
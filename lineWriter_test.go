@@ -0,0 +1,33 @@
+package easylogger
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_Writer(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), colorMode: int32(ColorNever)}
+	l.EnableGID(false)
+	l.SetLevel(InfoLevel)
+
+	w := l.Writer()
+	fmt.Fprint(w, "first line\nsecond ")
+	assert.Equal(t, "[INFO ] first line\n", buf.String())
+	fmt.Fprint(w, "line\r\n\n[ERROR] not parsed\nlast")
+	assert.Nil(t, w.Close())
+	assert.Equal(t, "[INFO ] first line\n[INFO ] second line\n[INFO ] [ERROR] not parsed\n[INFO ] last\n", buf.String())
+
+	buf.Reset()
+	w = l.WriterLevel(WarnLevel)
+	w.ParseLevels = true
+	fmt.Fprint(w, "[ERROR] failed\n  [warning] deprecated\n[debug] filtered\n[unknown] kept\nplain\n")
+	assert.Equal(t, "[ERROR] failed\n[WARN ] deprecated\n[WARN ] [unknown] kept\n[WARN ] plain\n", buf.String())
+
+	buf.Reset()
+	fmt.Fprint(w, strings.Repeat("x", maxPendingLine))
+	assert.Equal(t, maxPendingLine+len("[WARN ] \n"), buf.Len())
+}
@@ -0,0 +1,59 @@
+package easylogger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalLogfmt encodes the entry as a single logfmt line without a trailing newline: space
+// separated key=value pairs with the keys time, level, gid, caller, func, msg, stack and the
+// fields sorted by key, the fields named like the fixed keys being prefixed with "fields.".
+func (e Entry) MarshalLogfmt() []byte {
+	var sb strings.Builder
+	sb.WriteString("time=" + e.Time.Format(time.RFC3339Nano))
+	sb.WriteString(" level=" + e.Level.String())
+	if e.GID != 0 {
+		sb.WriteString(" gid=" + strconv.FormatUint(e.GID, 10))
+	}
+	if e.Caller != "" {
+		sb.WriteString(" caller=" + logfmtValue(e.Caller))
+	}
+	if e.Function != "" {
+		sb.WriteString(" func=" + logfmtValue(e.Function))
+	}
+	sb.WriteString(" msg=" + logfmtValue(e.Message))
+	if e.Stack != "" {
+		sb.WriteString(" stack=" + logfmtValue(e.Stack))
+	}
+	for _, k := range e.Fields.keys() {
+		key := logfmtKey(k)
+		if reservedKeys[key] {
+			key = "fields." + key
+		}
+		sb.WriteString(" " + key + "=" + logfmtValue(jsonValue(e.Fields[k])))
+	}
+	return []byte(sb.String())
+}
+
+// logfmtKey replaces the characters a logfmt key can't hold with '_'
+func logfmtKey(k string) string {
+	if k == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, k)
+}
+
+// logfmtValue renders v, quoted if it is empty or holds spaces, quotes, '=' or control characters
+func logfmtValue(v interface{}) string {
+	s := textValue(v)
+	if !strings.HasPrefix(s, `"`) && strings.IndexFunc(s, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntry_MarshalLogfmt(t *testing.T) {
+	e := Entry{Time: time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC), Level: InfoLevel, GID: 3, Caller: "main.go:7",
+		Message: "user logged in", Fields: Fields{"user": 42, "msg": "x", "bad key": "a=b", "err": errors.New("denied")}}
+	assert.Equal(t, `time=2021-09-01T12:00:00Z level=INFO gid=3 caller=main.go:7 msg="user logged in" `+
+		`bad_key="a=b" err=denied fields.msg=x user=42`, string(e.MarshalLogfmt()))
+
+	e = Entry{Level: ErrorLevel, Message: "", Stack: "main.main()\n\tmain.go:7\n"}
+	line := string(e.MarshalLogfmt())
+	assert.Contains(t, line, ` msg="" stack="main.main()\n\tmain.go:7\n"`)
+	assert.False(t, strings.Contains(line, "\n"))
+}
+
+func TestEasyLogger_LogfmtFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "prefix "}
+	l.SetFormat(LogfmtFormat)
+	l.With("user", 42).Warn("hello world")
+	line := buf.String()
+	assert.True(t, strings.HasPrefix(line, "time="))
+	assert.Regexp(t, `level=WARN gid=\d+ caller=logfmt_test.go:\d+ msg="hello world" user=42\n$`, line)
+
+	f, err := ParseFormat("logfmt")
+	assert.Nil(t, err)
+	assert.Equal(t, LogfmtFormat, f)
+	assert.Equal(t, "logfmt", LogfmtFormat.String())
+}
@@ -0,0 +1,77 @@
+// +build logrus
+
+package easylogger
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+// LogrusHook is a logrus.Hook writing the entries with an EasyLogger, see AsLogrusHook
+type LogrusHook struct {
+	el *EasyLogger
+}
+
+// ensure we always implement logrus.Hook
+var _ logrus.Hook = (*LogrusHook)(nil)
+
+// AsLogrusHook returns a logrus.Hook writing the entries of a logrus logger with this logger,
+// so that the code using logrus gets the rotating files without changing its calls. Build with
+// -tags logrus, and discard the output of logrus to avoid writing the entries twice:
+//
+//	logrus.SetOutput(ioutil.Discard)
+//	logrus.AddHook(el.AsLogrusHook())
+//
+// The logrus fields become fields, the caller is used with logrus.SetReportCaller(true). FATAL
+// and PANIC entries are only logged, logrus exits or panics itself.
+func (this *EasyLogger) AsLogrusHook() *LogrusHook {
+	return &LogrusHook{el: this}
+}
+
+// Levels implements logrus.Hook.
+func (h *LogrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *LogrusHook) Fire(e *logrus.Entry) error {
+	level := logrusLevel(e.Level)
+	if !h.el.Enabled(level) {
+		return nil
+	}
+	el := h.el
+	if len(e.Data) > 0 {
+		fields := make(Fields, len(e.Data))
+		for k, v := range e.Data {
+			if err, ok := v.(error); ok {
+				v = fmt.Sprint(err)
+			}
+			fields[k] = v
+		}
+		el = el.WithFields(fields)
+	}
+	var pc uintptr
+	if e.Caller != nil {
+		pc = e.Caller.PC
+	}
+	return el.emitAt(level, e.Message, pc)
+}
+
+// logrusLevel returns the Level of the logrus level l
+func logrusLevel(l logrus.Level) Level {
+	switch l {
+	case logrus.PanicLevel:
+		return PanicLevel
+	case logrus.FatalLevel:
+		return FatalLevel
+	case logrus.ErrorLevel:
+		return ErrorLevel
+	case logrus.WarnLevel:
+		return WarnLevel
+	case logrus.InfoLevel:
+		return InfoLevel
+	case logrus.DebugLevel:
+		return DebugLevel
+	}
+	return TraceLevel
+}
@@ -0,0 +1,26 @@
+// +build logrus
+
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEasyLogger_AsLogrusHook(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetLevel(InfoLevel)
+	hook := l.AsLogrusHook()
+	assert.Equal(t, logrus.AllLevels, hook.Levels())
+
+	assert.Nil(t, hook.Fire(&logrus.Entry{Level: logrus.DebugLevel, Message: "dropped"}))
+	assert.Nil(t, hook.Fire(&logrus.Entry{Level: logrus.WarnLevel, Message: "hello",
+		Data: logrus.Fields{"user": 42, "err": errors.New("boom")}}))
+	assert.Contains(t, buf.String(), WARN)
+	assert.Contains(t, buf.String(), ", hello err=boom user=42\n")
+	assert.NotContains(t, buf.String(), "dropped")
+}
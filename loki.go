@@ -0,0 +1,186 @@
+package easylogger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// LokiConfig configures a LokiSink
+type LokiConfig struct {
+	// URL is the push API of Loki, the default is "http://127.0.0.1:3100/loki/api/v1/push".
+	URL string
+
+	// App and Host are the "app" and "host" labels of the entries, the defaults are the name of
+	// the executable and os.Hostname. The entries are also labeled with their "level".
+	App  string
+	Host string
+
+	// Labels are added to the labels of the entries, e.g. {"env": "prod"}. Keep them few and
+	// static, every combination of labels is a Loki stream.
+	Labels map[string]string
+
+	// BatchSize is the maximum number of entries pushed at once, the default is 500.
+	BatchSize int
+
+	// BatchWait is the maximum time an entry waits for its batch to fill, the default is
+	// 1 second.
+	BatchWait time.Duration
+
+	// BufferSize is the maximum number of entries waiting to be pushed, the default is 10000.
+	BufferSize int
+
+	// Retry is how the pushing of a batch is retried, the default is DefaultRetryPolicy. The
+	// batches rejected by Loki with a client error other than 429 are not retried.
+	Retry *RetryPolicy
+
+	// Client sends the requests, the default is an http.Client with a timeout of 10 seconds.
+	Client *http.Client
+
+	// Header is added to the requests, e.g. for authentication or the X-Scope-OrgID of a
+	// multi-tenant Loki.
+	Header http.Header
+}
+
+// LokiSink is an EntrySink pushing the entries to Grafana Loki in batches, in the background,
+// see NewLokiSink
+type LokiSink struct {
+	config  LokiConfig
+	ch      chan Entry
+	done    chan struct{}
+	dropped uint64 // accessed atomically
+	gate    sinkGate
+}
+
+// NewLokiSink returns a LokiSink pushing the entries in the background, as JSON lines. The
+// entries are buffered while Loki is unreachable, then dropped once the buffer is full.
+//
+//	loki := easylogger.NewLokiSink(easylogger.LokiConfig{URL: "http://loki:3100/loki/api/v1/push", App: "billing"})
+//	l.AddSink(loki)
+//	defer loki.Close()
+func NewLokiSink(config LokiConfig) *LokiSink {
+	if config.URL == "" {
+		config.URL = "http://127.0.0.1:3100/loki/api/v1/push"
+	}
+	if config.App == "" {
+		config.App = filepath.Base(os.Args[0])
+	}
+	if config.Host == "" {
+		config.Host, _ = os.Hostname()
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+	if config.BatchWait <= 0 {
+		config.BatchWait = time.Second
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.Retry == nil {
+		config.Retry = &DefaultRetryPolicy
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	config.Retry = withStatusRetry(*config.Retry)
+
+	s := &LokiSink{config: config, ch: make(chan Entry, config.BufferSize), done: make(chan struct{})}
+	go s.run()
+	return s
+}
+
+// WriteEntry implements EntrySink.
+func (s *LokiSink) WriteEntry(e Entry) error {
+	err := s.gate.send(func() bool {
+		select {
+		case s.ch <- e:
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return err
+}
+
+// Dropped returns the number of entries dropped because the buffer was full or they could
+// not be pushed.
+func (s *LokiSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close pushes the buffered entries, then stops the sink.
+func (s *LokiSink) Close() error {
+	s.gate.close(func() { close(s.ch) })
+	<-s.done
+	return nil
+}
+
+// run pushes the entries in batches
+func (s *LokiSink) run() {
+	defer close(s.done)
+	runBatches(s.ch, s.config.BatchSize, s.config.BatchWait, s.push)
+}
+
+// push sends batch to Loki
+func (s *LokiSink) push(batch []Entry) {
+	body, err := s.request(batch)
+	if err == nil {
+		err = s.config.Retry.Do(context.Background(), func() error {
+			return postJSON(s.config.Client, s.config.URL, s.config.Header, body)
+		})
+	}
+	if err != nil {
+		atomic.AddUint64(&s.dropped, uint64(len(batch)))
+		diagf(CodeSinkWrite, "%d entries not pushed to %s: %v", len(batch), s.config.URL, err)
+	}
+}
+
+// lokiStream is a stream of a push request: its labels and its [timestamp, line] values
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// request renders batch as the JSON body of a push request, with a stream per level
+func (s *LokiSink) request(batch []Entry) ([]byte, error) {
+	req := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	streams := map[Level]*lokiStream{}
+	for _, e := range batch {
+		st := streams[e.Level]
+		if st == nil {
+			st = &lokiStream{Stream: s.labels(e.Level)}
+			streams[e.Level] = st
+			req.Streams = append(req.Streams, st)
+		}
+		line, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		st.Values = append(st.Values, [2]string{strconv.FormatInt(e.Time.UnixNano(), 10), string(line)})
+	}
+	return json.Marshal(req)
+}
+
+// labels returns the labels of the entries of the given level
+func (s *LokiSink) labels(level Level) map[string]string {
+	labels := make(map[string]string, len(s.config.Labels)+3)
+	for k, v := range s.config.Labels {
+		labels[k] = v
+	}
+	labels["app"] = s.config.App
+	labels["host"] = s.config.Host
+	labels["level"] = strings.ToLower(level.String())
+	return labels
+}
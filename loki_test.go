@@ -0,0 +1,80 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type lokiPush struct {
+	Streams []struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	} `json:"streams"`
+}
+
+func TestLokiSink(t *testing.T) {
+	var mu sync.Mutex
+	var pushes []lokiPush
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "tenant", r.Header.Get("X-Scope-OrgID"))
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var p lokiPush
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+		pushes = append(pushes, p)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := NewLokiSink(LokiConfig{URL: srv.URL, App: "billing", Host: "h1", Labels: map[string]string{"env": "prod"},
+		BatchSize: 2, BatchWait: time.Hour, Header: http.Header{"X-Scope-OrgID": {"tenant"}},
+		Retry: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}})
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	l.AddSink(s)
+	l.Info("first")
+	l.Error("second")
+	l.Info("third")
+	assert.Nil(t, s.Close())
+	assert.Equal(t, uint64(0), s.Dropped())
+
+	// a full batch, then the rest on Close, the first push being retried
+	assert.Len(t, pushes, 2)
+	assert.Len(t, pushes[0].Streams, 2)
+	assert.Equal(t, map[string]string{"app": "billing", "host": "h1", "level": "info", "env": "prod"}, pushes[0].Streams[0].Stream)
+	assert.Equal(t, "error", pushes[0].Streams[1].Stream["level"])
+	assert.Contains(t, pushes[0].Streams[0].Values[0][1], `"msg":"first"`)
+	assert.Contains(t, pushes[0].Streams[1].Values[0][1], `"msg":"second"`)
+	assert.Len(t, pushes[1].Streams, 1)
+	assert.Contains(t, pushes[1].Streams[0].Values[0][1], `"msg":"third"`)
+}
+
+func TestLokiSink_Rejected(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		http.Error(w, "entry too far behind", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s := NewLokiSink(LokiConfig{URL: srv.URL, BatchWait: time.Millisecond,
+		Retry: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}})
+	assert.Nil(t, s.WriteEntry(Entry{Time: time.Now(), Level: WarnLevel, Message: "old"}))
+	assert.Nil(t, s.Close())
+	// client errors are not retried
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, uint64(1), s.Dropped())
+}
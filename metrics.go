@@ -0,0 +1,104 @@
+package easylogger
+
+import (
+	"expvar"
+	"fmt"
+	"github.com/natefinch/lumberjack"
+	"os"
+	"sync/atomic"
+)
+
+// LogStats are the counters of the logging activity of an EasyLogger and its clones, see Stats
+type LogStats struct {
+	Writes       uint64           // entries written
+	Entries      map[Level]uint64 // entries written, by level
+	Bytes        uint64           // bytes written to the log files and the JSON output
+	Errors       uint64           // entries lost because all the outputs failed
+	Dropped      uint64           // entries dropped by the sampling, the rate limit, the field policy or a full async queue
+	QueueDepth   int              // entries waiting in the async queue, see EnableAsync
+	Rotations    uint64           // rotations of the time rotating log files
+	FileSize     int64            // size of the current log file
+	OpenFiles    int              // log files open by all the Loggers, see OpenFiles
+	MaxOpenFiles int              // limit of the open log files, 0 if none, see SetMaxOpenFiles
+	Compression  CompressionStats // compression of the rotated files, shared by all the loggers
+}
+
+// logMetrics holds the counters of LogStats, it is shared with the clones
+type logMetrics struct {
+	entries [PanicLevel - TraceLevel + 1]uint64
+	bytes   uint64
+	errors  uint64
+	dropped uint64
+}
+
+func (m *logMetrics) written(level Level, n int) {
+	if m == nil || n <= 0 {
+		return
+	}
+	if level >= TraceLevel && level <= PanicLevel {
+		atomic.AddUint64(&m.entries[level-TraceLevel], 1)
+	}
+	atomic.AddUint64(&m.bytes, uint64(n))
+}
+
+func (m *logMetrics) failed() {
+	if m != nil {
+		atomic.AddUint64(&m.errors, 1)
+	}
+}
+
+func (m *logMetrics) drop() {
+	if m != nil {
+		atomic.AddUint64(&m.dropped, 1)
+	}
+}
+
+// Stats returns the counters of the logging activity, they are shared with the clones.
+func (this *EasyLogger) Stats() LogStats {
+	stats := LogStats{
+		Entries:     make(map[Level]uint64),
+		Dropped:     this.DroppedEntries(),
+		Compression: GetCompressionStats(),
+	}
+	stats.OpenFiles, stats.MaxOpenFiles = OpenFiles()
+	if m := this.metrics; m != nil {
+		for i := range m.entries {
+			if n := atomic.LoadUint64(&m.entries[i]); n > 0 {
+				stats.Entries[TraceLevel+Level(i)] = n
+				stats.Writes += n
+			}
+		}
+		stats.Bytes = atomic.LoadUint64(&m.bytes)
+		stats.Errors = atomic.LoadUint64(&m.errors)
+		stats.Dropped += atomic.LoadUint64(&m.dropped)
+	}
+	if this.async != nil {
+		stats.QueueDepth = len(this.async.ch)
+	}
+	for i, f := range this.files {
+		switch f := f.(type) {
+		case *Logger:
+			stats.Rotations += uint64(f.Rotations())
+			if i == 0 {
+				stats.FileSize = f.Size()
+			}
+		case *lumberjack.Logger:
+			if i == 0 {
+				if info, err := os.Stat(f.Filename); err == nil {
+					stats.FileSize = info.Size()
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// PublishExpvar publishes the Stats as the expvar variable name, so that they are served by
+// /debug/vars along with the memstats. It fails if name is already published.
+func (this *EasyLogger) PublishExpvar(name string) error {
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %s already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() interface{} { return this.Stats() }))
+	return nil
+}
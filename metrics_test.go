@@ -0,0 +1,75 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_Stats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer SetFieldPolicy(nil)
+
+	l := NewEasyLogger(WithDirectory(dir), WithLevel(DebugLevel))
+	defer l.Close()
+	l.Debug("debug")
+	l.Info("info")
+	l.Clone().Error("error")
+	l.Trace("below the level")
+	SetFieldPolicy(&FieldPolicy{Required: []string{"service"}, Reject: true})
+	l.Info("rejected")
+	SetFieldPolicy(nil)
+
+	stats := l.Stats()
+	assert.Equal(t, uint64(3), stats.Writes)
+	assert.Equal(t, map[Level]uint64{DebugLevel: 1, InfoLevel: 1, ErrorLevel: 1}, stats.Entries)
+	assert.Equal(t, uint64(0), stats.Errors)
+	assert.Equal(t, uint64(1), stats.Dropped)
+	assert.Equal(t, uint64(0), stats.Rotations)
+	b, err := ioutil.ReadFile(filepath.Join(dir, time.Now().Format(FileNameTimeFormat)+FileNameExt))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(len(b)), stats.Bytes)
+	assert.Equal(t, int64(len(b)), stats.FileSize)
+	open, max := OpenFiles()
+	assert.True(t, open >= 1)
+	assert.Equal(t, open, stats.OpenFiles)
+	assert.Equal(t, max, stats.MaxOpenFiles)
+
+	assert.Nil(t, l.Rotate())
+	assert.Equal(t, uint64(1), l.Stats().Rotations)
+	assert.Equal(t, int64(0), l.Stats().FileSize)
+
+	// no counters for the loggers not created by NewEasyLogger
+	l2 := &EasyLogger{out: newSink(ioutil.Discard)}
+	l2.Info("info")
+	assert.Equal(t, 0, len(l2.Stats().Entries))
+
+	l3 := &EasyLogger{out: newSink(failingWriter{}), metrics: &logMetrics{}}
+	l3.Info("lost")
+	assert.Equal(t, uint64(1), l3.Stats().Errors)
+	assert.Equal(t, uint64(0), l3.Stats().Writes)
+}
+
+func TestEasyLogger_PublishExpvar(t *testing.T) {
+	l := &EasyLogger{out: newSink(ioutil.Discard), metrics: &logMetrics{}}
+	l.Warn("warn")
+	// the expvar names are global, unique to run the test more than once
+	name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+	assert.Nil(t, l.PublishExpvar(name))
+	assert.NotNil(t, l.PublishExpvar(name))
+
+	var stats LogStats
+	assert.Nil(t, json.Unmarshal([]byte(expvar.Get(name).String()), &stats))
+	assert.Equal(t, uint64(1), stats.Writes)
+	assert.Equal(t, map[Level]uint64{WarnLevel: 1}, stats.Entries)
+	open, _ := OpenFiles()
+	assert.Equal(t, open, stats.OpenFiles)
+}
@@ -0,0 +1,187 @@
+package easylogger
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader is the header of the request IDs read and set by HTTPMiddleware
+const RequestIDHeader = "X-Request-ID"
+
+// AccessLogFormat is the layout of the entries of HTTPMiddleware
+type AccessLogFormat int
+
+const (
+	// AccessLogFields logs the requests as "GET /orders 200" with the fields method, path,
+	// status, latency_ms, bytes, remote_ip and request_id, which are JSON keys with the JSON
+	// Format. It is the default.
+	AccessLogFields AccessLogFormat = iota
+
+	// AccessLogCombined logs the requests in the combined log format of the Apache and nginx
+	// access logs, with the fields latency_ms and request_id.
+	AccessLogCombined
+)
+
+// SetAccessLogFormat sets the layout of the entries of HTTPMiddleware. It should be called
+// before the logger is used.
+func (this *EasyLogger) SetAccessLogFormat(f AccessLogFormat) {
+	this.accessLog = f
+}
+
+// WithAccessLogFormat sets the layout of the entries of HTTPMiddleware, see SetAccessLogFormat.
+func WithAccessLogFormat(f AccessLogFormat) Option {
+	return func(l *EasyLogger) {
+		l.SetAccessLogFormat(f)
+	}
+}
+
+// requestIDContextKey is the context key of the request IDs
+type requestIDContextKey struct{}
+
+// RequestIDFromContext returns the request ID set by HTTPMiddleware in the context of the
+// request, "" if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// RequestIDExtractor adds the request ID set by HTTPMiddleware as the request_id field of the
+// entries logged with the context of the request, see AddContextExtractor.
+var RequestIDExtractor ContextExtractor = ContextValue("request_id", requestIDContextKey{})
+
+// HTTPMiddleware logs the requests served by next once they are served, at the INFO level, WARN
+// for the 4xx statuses and ERROR for the 5xx ones, see SetAccessLogFormat:
+//
+//	http.ListenAndServe(":8080", l.HTTPMiddleware(mux))
+//
+// The request ID is read from the X-Request-ID header, or generated with NewID, then set in the
+// response header and in the context of the request, see RequestIDFromContext.
+func (this *EasyLogger) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id, r := withRequestID(w.Header(), r)
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		this.logRequest(r, remoteIP(r), rec.status, rec.bytes, id, start)
+	})
+}
+
+// withRequestID returns the request ID of r, read from its header or generated, and r with the
+// ID in its context, the ID is set in the response header h
+func withRequestID(h http.Header, r *http.Request) (string, *http.Request) {
+	id := r.Header.Get(RequestIDHeader)
+	if id == "" {
+		id = NewID()
+	}
+	h.Set(RequestIDHeader, id)
+	return id, r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id))
+}
+
+// remoteIP returns the IP address of the client of r, without its port
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// logRequest logs a request served, with the status 200 if it is 0
+func (this *EasyLogger) logRequest(r *http.Request, remoteIP string, status int, bytes int64, id string, start time.Time) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	level := InfoLevel
+	switch {
+	case status >= 500:
+		level = ErrorLevel
+	case status >= 400:
+		level = WarnLevel
+	}
+	if !this.Enabled(level) {
+		return
+	}
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	var l *EasyLogger
+	var msg string
+	if this.accessLog == AccessLogCombined {
+		l = this.WithFields(Fields{"latency_ms": latency, "request_id": id})
+		msg = combinedLogLine(r, remoteIP, status, bytes, start)
+	} else {
+		l = this.WithFields(Fields{"method": r.Method, "path": r.URL.Path, "status": status, "latency_ms": latency,
+			"bytes": bytes, "remote_ip": remoteIP, "request_id": id})
+		msg = r.Method + " " + r.URL.Path + " " + strconv.Itoa(status)
+	}
+	l.emitAt(level, msg, 0)
+}
+
+// combinedLogLine returns the line of a request in the combined log format, e.g.
+// 127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a.gif HTTP/1.0" 200 2326 "http://example.com/" "Mozilla/4.08"
+func combinedLogLine(r *http.Request, remoteIP string, status int, bytes int64, start time.Time) string {
+	user := "-"
+	if name, _, ok := r.BasicAuth(); ok && name != "" {
+		user = name
+	}
+	return fmt.Sprintf("%s - %s [%s] %q %d %d %q %q", remoteIP, user, start.Format("02/Jan/2006:15:04:05 -0700"),
+		r.Method+" "+r.RequestURI+" "+r.Proto, status, bytes, orDash(r.Referer()), orDash(r.UserAgent()))
+}
+
+// logPanic logs the panic v recovered while serving r, with the stack trace of the panic, it
+// is called by the deferred function recovering it
+func (this *EasyLogger) logPanic(r *http.Request, v interface{}) {
+	// 0 is callers, 1 logPanic, 2 the deferred function, then runtime.gopanic and the function
+	// which panicked
+	pcs := callers(3)
+	for len(pcs) > 0 {
+		if f := runtime.FuncForPC(pcs[0] - 1); f == nil || !strings.HasPrefix(f.Name(), "runtime.") {
+			break
+		}
+		pcs = pcs[1:]
+	}
+	this.emitStack(ErrorLevel, fmt.Sprintf("panic serving %s %s: %v", r.Method, r.URL.Path, v), pcs)
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// responseRecorder records the status and the size of a response
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher for the streaming handlers.
+func (rec *responseRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,78 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEasyLogger_HTTPMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetFormat(JSONFormat)
+	var ctxID string
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+
+	req := httptest.NewRequest("GET", "/orders/42?x=1", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "req-1", ctxID)
+	assert.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "WARN", m["level"])
+	assert.Equal(t, "GET /orders/42 404", m["msg"])
+	assert.Equal(t, "GET", m["method"])
+	assert.Equal(t, "/orders/42", m["path"])
+	assert.Equal(t, float64(404), m["status"])
+	assert.Equal(t, float64(9), m["bytes"])
+	assert.Equal(t, "192.0.2.1", m["remote_ip"])
+	assert.Equal(t, "req-1", m["request_id"])
+	assert.NotNil(t, m["latency_ms"])
+
+	// a request ID is generated if there is none
+	buf.Reset()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	assert.NotEqual(t, "", ctxID)
+	assert.Contains(t, buf.String(), ctxID)
+}
+
+func TestEasyLogger_HTTPMiddlewareCombined(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), colorMode: int32(ColorNever)}
+	l.EnableGID(false)
+	l.SetAccessLogFormat(AccessLogCombined)
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest("POST", "/login?next=%2F", nil)
+	req.SetBasicAuth("frank", "secret")
+	req.Header.Set("User-Agent", "curl/7.68.0")
+	req.Header.Set(RequestIDHeader, "req-2")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Regexp(t, `^\[INFO \] 192\.0\.2\.1 - frank \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] `+
+		`"POST /login\?next=%2F HTTP/1\.1" 200 2 "-" "curl/7\.68\.0" latency_ms=[\d.]+ request_id=req-2\n$`, buf.String())
+}
+
+func TestRequestIDExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.AddContextExtractor(RequestIDExtractor)
+	h := l.Clone().HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.InfoCtx(r.Context(), "handled")
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "req-3")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Contains(t, buf.String(), "handled request_id=req-3")
+}
@@ -0,0 +1,150 @@
+package easylogger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// appendMsgpack appends v encoded with MessagePack to b. The values other than nil, booleans,
+// numbers, strings, byte slices, slices and maps of them are encoded as their text, the times
+// as RFC 3339 strings.
+func appendMsgpack(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case string:
+		return appendMsgpackString(b, v)
+	case []byte:
+		b = appendMsgpackHeader(b, len(v), 0, 0, 0xc4, 0xc5, 0xc6)
+		return append(b, v...)
+	case int:
+		return appendMsgpackInt(b, int64(v))
+	case int8:
+		return appendMsgpackInt(b, int64(v))
+	case int16:
+		return appendMsgpackInt(b, int64(v))
+	case int32:
+		return appendMsgpackInt(b, int64(v))
+	case int64:
+		return appendMsgpackInt(b, v)
+	case uint:
+		return appendMsgpackUint(b, uint64(v))
+	case uint8:
+		return appendMsgpackUint(b, uint64(v))
+	case uint16:
+		return appendMsgpackUint(b, uint64(v))
+	case uint32:
+		return appendMsgpackUint(b, uint64(v))
+	case uint64:
+		return appendMsgpackUint(b, v)
+	case float32:
+		b = append(b, 0xca)
+		return appendUint32(b, math.Float32bits(v))
+	case float64:
+		b = append(b, 0xcb)
+		return appendUint64(b, math.Float64bits(v))
+	case time.Time:
+		return appendMsgpackString(b, v.Format(time.RFC3339Nano))
+	case Fields:
+		return appendMsgpackMap(b, v)
+	case map[string]interface{}:
+		return appendMsgpackMap(b, v)
+	case []interface{}:
+		b = appendMsgpackHeader(b, len(v), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, e := range v {
+			b = appendMsgpack(b, e)
+		}
+		return b
+	case error, fmt.Stringer:
+		// "<nil>" for the nil pointers, see jsonValue
+		return appendMsgpackString(b, fmt.Sprint(v))
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		b = appendMsgpackHeader(b, rv.Len(), 0x90, 16, 0, 0xdc, 0xdd)
+		for i := 0; i < rv.Len(); i++ {
+			b = appendMsgpack(b, rv.Index(i).Interface())
+		}
+		return b
+	}
+	return appendMsgpackString(b, fmt.Sprint(v))
+}
+
+// appendMsgpackMap appends the map m, its keys sorted
+func appendMsgpackMap(b []byte, m map[string]interface{}) []byte {
+	b = appendMsgpackHeader(b, len(m), 0x80, 16, 0, 0xde, 0xdf)
+	for _, k := range Fields(m).keys() {
+		b = appendMsgpackString(b, k)
+		b = appendMsgpack(b, m[k])
+	}
+	return b
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	b = appendMsgpackHeader(b, len(s), 0xa0, 32, 0xd9, 0xda, 0xdb)
+	return append(b, s...)
+}
+
+// appendMsgpackHeader appends the header of a string, binary, array or map of n elements: the
+// fix type if n is less than fixMax, or the type with the 8, 16 or 32-bit length, c8 being zero
+// for the arrays and the maps which have no 8-bit length type
+func appendMsgpackHeader(b []byte, n int, fix byte, fixMax int, c8, c16, c32 byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(b, fix|byte(n))
+	case c8 != 0 && n <= math.MaxUint8:
+		return append(b, c8, byte(n))
+	case n <= math.MaxUint16:
+		return append(b, c16, byte(n>>8), byte(n))
+	}
+	return appendUint32(append(b, c32), uint32(n))
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return appendMsgpackUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return append(b, 0xd1, byte(n>>8), byte(n))
+	case n >= math.MinInt32:
+		return appendUint32(append(b, 0xd2), uint32(n))
+	}
+	return appendUint64(append(b, 0xd3), uint64(n))
+}
+
+func appendMsgpackUint(b []byte, n uint64) []byte {
+	switch {
+	case n < 128:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xcd, byte(n>>8), byte(n))
+	case n <= math.MaxUint32:
+		return appendUint32(append(b, 0xce), uint32(n))
+	}
+	return appendUint64(append(b, 0xcf), n)
+}
+
+func appendUint32(b []byte, n uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, n uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,39 @@
+package easylogger
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendMsgpack(t *testing.T) {
+	for _, c := range []struct {
+		v    interface{}
+		want []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{5, []byte{0x05}},
+		{-5, []byte{0xfb}},
+		{-100, []byte{0xd0, 0x9c}},
+		{300, []byte{0xcd, 0x01, 0x2c}},
+		{uint64(1) << 40, []byte{0xcf, 0, 0, 1, 0, 0, 0, 0, 0}},
+		{1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"hi", []byte{0xa2, 'h', 'i'}},
+		{[]byte{1}, []byte{0xc4, 0x01, 0x01}},
+		{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{Fields{"b": 1, "a": "x"}, []byte{0x82, 0xa1, 'a', 0xa1, 'x', 0xa1, 'b', 0x01}},
+		{errors.New("e"), []byte{0xa1, 'e'}},
+		{time.Duration(0), []byte{0xa2, '0', 's'}},
+		{struct{}{}, []byte{0xa2, '{', '}'}},
+	} {
+		assert.Equal(t, c.want, appendMsgpack(nil, c.v))
+	}
+
+	long := strings.Repeat("x", 40)
+	assert.Equal(t, []byte{0xd9, 40}, appendMsgpack(nil, long)[:2])
+	assert.Equal(t, []byte{0xda, 0x01, 0x00}, appendMsgpack(nil, strings.Repeat("x", 256))[:3])
+	assert.Equal(t, []byte{0xdc, 0x00, 0x10}, appendMsgpack(nil, make([]bool, 16))[:3])
+}
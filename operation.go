@@ -0,0 +1,56 @@
+package easylogger
+
+import (
+	"context"
+	"time"
+)
+
+// The fields of the completion entries of Begin
+const (
+	OperationField = "operation"
+	OutcomeField   = "outcome"
+	DurationField  = "duration_ms"
+	ErrorField     = "error"
+
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Begin starts timing the operation op and returns the function to call once it is done, which
+// logs a single completion entry with the fields, the duration in milliseconds, the outcome and
+// the error if any, at the INFO level on success and ERROR on failure:
+//
+//	done := l.Begin(ctx, "charge_card", easylogger.Fields{"order": id})
+//	err := charge(ctx, card)
+//	done(err)
+//
+// The entry is logged with ctx like by the Ctx methods.
+func (this *EasyLogger) Begin(ctx context.Context, op string, fields Fields) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		elapsed := time.Since(start)
+
+		level, msg, outcome := InfoLevel, op+" succeeded", OutcomeSuccess
+		if err != nil {
+			level, msg, outcome = ErrorLevel, op+" failed", OutcomeFailure
+		}
+		level, ok := this.ctxLevel(ctx, level)
+		if !ok {
+			return
+		}
+		result := Fields{
+			OperationField: op,
+			OutcomeField:   outcome,
+			DurationField:  float64(elapsed) / float64(time.Millisecond),
+		}
+		if err != nil {
+			result[ErrorField] = err.Error()
+		}
+		for k, v := range fields {
+			if _, ok := result[k]; !ok {
+				result[k] = v
+			}
+		}
+		this.ctxLogger(ctx).WithFields(result).output(level, msg)
+	}
+}
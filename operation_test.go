@@ -0,0 +1,52 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_Begin(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetFormat(JSONFormat)
+
+	done := l.Begin(context.Background(), "charge_card", Fields{"order": "A-1", OutcomeField: "ignored"})
+	time.Sleep(10 * time.Millisecond)
+	done(nil)
+	done = l.Begin(context.Background(), "refund", nil)
+	done(errors.New("declined"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.Equal(t, "INFO", m["level"])
+	assert.Equal(t, "charge_card succeeded", m["msg"])
+	assert.Equal(t, "charge_card", m[OperationField])
+	assert.Equal(t, OutcomeSuccess, m[OutcomeField])
+	assert.Equal(t, "A-1", m["order"])
+	assert.True(t, m[DurationField].(float64) >= 10)
+	assert.Nil(t, m[ErrorField])
+	assert.Contains(t, m["caller"], "operation_test.go")
+
+	m = nil
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &m))
+	assert.Equal(t, "ERROR", m["level"])
+	assert.Equal(t, OutcomeFailure, m[OutcomeField])
+	assert.Equal(t, "declined", m[ErrorField])
+
+	// the completion of a canceled operation follows the CanceledPolicy
+	buf.Reset()
+	l.SetCanceledPolicy(CanceledSuppress)
+	ctx, cancel := context.WithCancel(context.Background())
+	done = l.Begin(ctx, "aborted", nil)
+	cancel()
+	done(nil)
+	assert.Equal(t, "", buf.String())
+}
@@ -0,0 +1,253 @@
+package easylogger
+
+import (
+	"github.com/natefinch/lumberjack"
+	"os"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures an EasyLogger
+type Option func(l *EasyLogger)
+
+// WithPrefix sets the prefix of the text lines, see log.Logger.SetPrefix.
+func WithPrefix(prefix string) Option {
+	return func(l *EasyLogger) {
+		l.prefix = prefix
+	}
+}
+
+// WithFlags sets the flags of the text lines, e.g. log.Ldate|log.Lmicroseconds.
+func WithFlags(flags int) Option {
+	return func(l *EasyLogger) {
+		l.flags = flags
+	}
+}
+
+// WithLevel sets the minimum level of the entries to log.
+func WithLevel(level Level) Option {
+	return func(l *EasyLogger) {
+		l.SetLevel(level)
+	}
+}
+
+// WithSampler sets the Sampler of the sample stage.
+func WithSampler(s Sampler) Option {
+	return func(l *EasyLogger) {
+		l.sampler = s
+	}
+}
+
+// WithFieldEncrypter sets the encryption of the sensitive JSON fields.
+func WithFieldEncrypter(e *FieldEncrypter) Option {
+	return func(l *EasyLogger) {
+		l.encrypter = e
+	}
+}
+
+// Clone returns a new EasyLogger with the configuration of this one modified by opts.
+// The clone shares the outputs and their open files with this logger, but its configuration
+// is independent, e.g. to give a noisy subsystem its own settings. The level is the exception:
+// the clone follows the level of this logger until its own SetLevel, e.g. WithLevel. Clone is safe to call
+// while this logger is in use.
+func (this *EasyLogger) Clone(opts ...Option) *EasyLogger {
+	c := &EasyLogger{
+		strict:       atomic.LoadInt32(&this.strict),
+		lineFormat:   atomic.LoadInt32(&this.lineFormat),
+		out:          this.out,
+		console:      this.console,
+		colorMode:    atomic.LoadInt32(&this.colorMode),
+		maxMessage:   atomic.LoadInt32(&this.maxMessage),
+		singleWriter: atomic.LoadInt32(&this.singleWriter),
+		writeFailure: atomic.LoadInt32(&this.writeFailure),
+		consoleTTY:   this.consoleTTY,
+		prefix:       this.prefix,
+		flags:        this.flags,
+		jsonOut:      this.jsonOut,
+		encrypter:    this.encrypter,
+		redactions:   append([]*regexp.Regexp(nil), this.redactions...),
+		sampler:      this.sampler,
+		callerOn:     this.callerOn,
+		callerSkip:   this.callerSkip,
+		noGID:        this.noGID,
+		stackOn:      this.stackOn,
+		errStack:     this.errStack,
+		formatter:    this.formatter,
+		dedup:        this.dedup,
+		limiter:      this.limiter,
+		dir:          this.dir,
+		files:        this.files,
+		buffers:      this.buffers,
+		syncer:       this.syncer,
+		recent:       this.recent,
+		profiler:     this.profiler,
+		tracer:       this.tracer,
+		clock:        this.clock,
+		async:        this.async,
+		canceled:     this.canceled,
+		accessLog:    this.accessLog,
+		exitFunc:     this.exitFunc,
+		fields:       this.fields,
+		labels:       this.labels,
+		extractors:   this.extractors,
+		routes:       this.routes,
+		sinks:        this.sinks,
+		hooks:        this.hooks,
+		captures:     this.captures,
+		metrics:      this.metrics,
+	}
+	if sl := this.level.Load(); sl != nil {
+		c.level.Store(sl)
+	}
+	if ds := this.decorations.Load(); ds != nil {
+		c.decorations.Store(ds)
+	}
+	for i := range this.stages {
+		c.stages[i].disabled = atomic.LoadUint64(&this.stages[i].disabled)
+	}
+	this.health.mu.Lock()
+	c.health.minDiskFree = this.health.minDiskFree
+	c.health.maxSilence = this.health.maxSilence
+	c.health.maxQueueFill = this.health.maxQueueFill
+	this.health.mu.Unlock()
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// outputSetup holds the outputs to create set by the options of NewEasyLogger
+type outputSetup struct {
+	fileName   string
+	dir        string
+	maxSize    int
+	maxAge     int
+	maxBackups int
+	rotateDays int
+	localTime  bool
+	compress   bool
+	shared     bool
+	reopen     bool
+	fileMode   os.FileMode
+	dirMode    os.FileMode
+	dualFormat bool
+	errorFile  bool
+	console    bool
+	consoleMin Level
+
+	bufferSize    int
+	flushInterval time.Duration
+}
+
+// withSetup makes an Option modifying the outputs to create, it has no effect outside of NewEasyLogger
+func withSetup(f func(s *outputSetup)) Option {
+	return func(l *EasyLogger) {
+		if l.setup != nil {
+			f(l.setup)
+		}
+	}
+}
+
+// sizeRotatingFile returns the size rotating log file fileName according to the setup
+func (s *outputSetup) sizeRotatingFile(fileName string) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   fileName,
+		MaxSize:    s.maxSize,    // defaults to 100 megabytes
+		MaxAge:     s.maxAge,     // maximum number of days to retain old log files based on the timestamp encoded in their filename, default is not to remove old log files
+		MaxBackups: s.maxBackups, // maximum number of old log files to retain, default is to retain all old log files
+		LocalTime:  s.localTime,  // default is to use UTC time
+		Compress:   s.compress,   // compress the rotated files, default is not to compress
+	}
+}
+
+// timeRotatingFile returns the time rotating log files with the extension ext according to the setup
+func (s *outputSetup) timeRotatingFile(ext string) *Logger {
+	return &Logger{
+		Directory:     s.dir,
+		MaxDays:       s.rotateDays,
+		MaxBackups:    s.maxBackups,
+		MaxAge:        s.maxAge,
+		LocalTime:     s.localTime,
+		Compress:      s.compress,
+		Shared:        s.shared,
+		ReopenIfMoved: s.reopen,
+		FileMode:      s.fileMode,
+		DirMode:       s.dirMode,
+		FileExt:       ext,
+	}
+}
+
+// WithFile writes the entries to fileName, rotated when it reaches the size set by WithMaxSize.
+func WithFile(fileName string) Option {
+	return withSetup(func(s *outputSetup) { s.fileName = fileName })
+}
+
+// WithDirectory writes the entries to files in dir, rotated every WithRotateDays days.
+func WithDirectory(dir string) Option {
+	return withSetup(func(s *outputSetup) { s.dir = dir })
+}
+
+// WithMaxSize sets the size in megabytes of the file set by WithFile, 100 by default.
+func WithMaxSize(megabytes int) Option {
+	return withSetup(func(s *outputSetup) { s.maxSize = megabytes })
+}
+
+// WithMaxAge sets the number of days to retain the rotated files, zero keeps them.
+func WithMaxAge(days int) Option {
+	return withSetup(func(s *outputSetup) { s.maxAge = days })
+}
+
+// WithMaxBackups sets the number of rotated files to retain, zero keeps them.
+func WithMaxBackups(n int) Option {
+	return withSetup(func(s *outputSetup) { s.maxBackups = n })
+}
+
+// WithRotateDays sets the number of days covered by each file of WithDirectory, 1 by default.
+func WithRotateDays(days int) Option {
+	return withSetup(func(s *outputSetup) { s.rotateDays = days })
+}
+
+// WithLocalTime uses the local time instead of UTC in the names of the files.
+func WithLocalTime(local bool) Option {
+	return withSetup(func(s *outputSetup) { s.localTime = local })
+}
+
+// WithCompress compresses the rotated files with gzip.
+func WithCompress(compress bool) Option {
+	return withSetup(func(s *outputSetup) { s.compress = compress })
+}
+
+// WithSharedFiles coordinates the files of WithDirectory with the other processes writing into
+// the same directory, see Logger.Shared.
+func WithSharedFiles(shared bool) Option {
+	return withSetup(func(s *outputSetup) { s.shared = shared })
+}
+
+// WithReopenIfMoved reopens the files of WithDirectory when other programs rename or remove
+// them, see Logger.ReopenIfMoved.
+func WithReopenIfMoved(reopen bool) Option {
+	return withSetup(func(s *outputSetup) { s.reopen = reopen })
+}
+
+// WithFileMode sets the mode of the files of WithDirectory, see Logger.FileMode.
+func WithFileMode(mode os.FileMode) Option {
+	return withSetup(func(s *outputSetup) { s.fileMode = mode })
+}
+
+// WithDirMode sets the mode of the directory of WithDirectory if it is created, see Logger.DirMode.
+func WithDirMode(mode os.FileMode) Option {
+	return withSetup(func(s *outputSetup) { s.dirMode = mode })
+}
+
+// WithDualFormat also writes every entry as a JSON line into a ".jsonl" sidecar file next to
+// each file of WithDirectory, see NewTimeRotatingDualFormatEasyLogger.
+func WithDualFormat(dual bool) Option {
+	return withSetup(func(s *outputSetup) { s.dualFormat = dual })
+}
+
+// WithConsole also writes the entries to the standard output.
+func WithConsole(console bool) Option {
+	return withSetup(func(s *outputSetup) { s.console = console })
+}
@@ -0,0 +1,79 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/natefinch/lumberjack"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_Clone(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "main ", flags: 0}
+	assert.Nil(t, l.SetStageEnabled(StageEnrich, false))
+
+	c := l.Clone(WithPrefix("noisy "), WithSampler(func(e Entry) bool {
+		return strings.HasPrefix(e.Message, "keep")
+	}))
+	c.Info("keep me")
+	c.Info("drop me")
+	l.Info("drop me not")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "noisy "))
+	assert.Contains(t, lines[0], "GID 0, keep me")
+	assert.True(t, strings.HasPrefix(lines[1], "main "))
+	assert.Contains(t, lines[1], "drop me not")
+
+	// the clone has its own configuration
+	assert.Nil(t, c.SetStageEnabled(StageEnrich, true))
+	assert.False(t, l.PipelineStats()[2].Enabled)
+	assert.Equal(t, uint64(2), c.PipelineStats()[3].Count)
+	assert.Equal(t, uint64(1), c.PipelineStats()[5].Count)
+	assert.Equal(t, uint64(1), l.PipelineStats()[5].Count)
+}
+
+func TestNewEasyLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir), WithDualFormat(true), WithLocalTime(true),
+		WithLevel(WarnLevel), WithPrefix("app "))
+	assert.Nil(t, l.console)
+	assert.Equal(t, 2, len(l.files))
+	l.Info("dropped")
+	l.Warn("kept")
+
+	name := time.Now().Format(FileNameTimeFormat)
+	b, err := ioutil.ReadFile(filepath.Join(dir, name+FileNameExt))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(b), "app "))
+	assert.Contains(t, string(b), "kept")
+	assert.NotContains(t, string(b), "dropped")
+	b, err = ioutil.ReadFile(filepath.Join(dir, name+JSONFileNameExt))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"msg":"kept"`)
+
+	l = NewEasyLogger(WithFile(filepath.Join(dir, "size.log")), WithMaxSize(5), WithConsole(true))
+	assert.NotNil(t, l.console)
+	lum := l.files[0].(*lumberjack.Logger)
+	assert.Equal(t, 5, lum.MaxSize)
+	assert.Equal(t, dir, l.dir)
+
+	// without file the entries go to the console
+	l = NewEasyLogger()
+	assert.NotNil(t, l.console)
+	assert.Nil(t, l.files)
+
+	// the output options only apply to NewEasyLogger
+	c := l.Clone(WithFile("ignored.log"))
+	assert.Nil(t, c.files)
+	assert.Nil(t, c.setup)
+}
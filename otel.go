@@ -0,0 +1,31 @@
+// +build otel
+
+package easylogger
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the fields of the OpenTelemetry trace and span IDs, see OTelTraceExtractor
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// when built with -tags otel, the Ctx methods of all the loggers add the IDs of the span of
+// their context to the entries
+func init() {
+	builtinExtractors = append(builtinExtractors, OTelTraceExtractor)
+}
+
+// OTelTraceExtractor is a ContextExtractor adding the trace and span IDs of the OpenTelemetry
+// span of ctx, if any, as "trace_id" and "span_id", so that the entries can be correlated with
+// the traces in Grafana or Jaeger. It is used by all the loggers when built with -tags otel.
+func OTelTraceExtractor(ctx context.Context) Fields {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return Fields{TraceIDField: sc.TraceID().String(), SpanIDField: sc.SpanID().String()}
+}
@@ -0,0 +1,35 @@
+// +build otel
+
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestOTelTraceExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	l.InfoCtx(ctx, "traced")
+	l.InfoCtx(context.Background(), "untraced")
+	assert.Contains(t, buf.String(), ", traced span_id=00f067aa0ba902b7 trace_id=4bf92f3577b34da6a3ce929d0e0e4736\n")
+	assert.Contains(t, buf.String(), ", untraced\n")
+
+	buf.Reset()
+	l.SetFormat(JSONFormat)
+	l.WarnCtx(ctx, "json")
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", m[TraceIDField])
+	assert.Equal(t, "00f067aa0ba902b7", m[SpanIDField])
+}
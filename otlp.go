@@ -0,0 +1,316 @@
+package easylogger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// otlpSeverities are the OpenTelemetry severity numbers of the levels: TRACE, DEBUG, INFO,
+// WARN, ERROR, FATAL and FATAL2
+var otlpSeverities = [...]int{1, 5, 9, 13, 17, 21, 22}
+
+// OTLPConfig configures an OTLPSink
+type OTLPConfig struct {
+	// Endpoint is the OTLP/HTTP logs endpoint of the collector, the default is
+	// "http://127.0.0.1:4318/v1/logs".
+	Endpoint string
+
+	// ServiceName is the "service.name" resource attribute, the default is the name of the
+	// executable.
+	ServiceName string
+
+	// ResourceAttributes are added to the resource of the entries, e.g.
+	// {"deployment.environment": "prod"}. "host.name" is set to os.Hostname by default.
+	ResourceAttributes map[string]string
+
+	// Header is added to the requests, e.g. for authentication.
+	Header http.Header
+
+	// BatchSize is the maximum number of entries exported at once, the default is 512.
+	BatchSize int
+
+	// BatchWait is the maximum time an entry waits for its batch to fill, the default is
+	// 1 second.
+	BatchWait time.Duration
+
+	// BufferSize is the maximum number of entries waiting to be exported, the default is 10000.
+	BufferSize int
+
+	// Retry is how the exporting of a batch is retried, the default is DefaultRetryPolicy. The
+	// batches rejected with a client error other than 429 are not retried.
+	Retry *RetryPolicy
+
+	// Client sends the requests, the default is an http.Client with a timeout of 10 seconds.
+	Client *http.Client
+}
+
+// OTLPSink is an EntrySink exporting the entries to an OpenTelemetry collector as OTLP log
+// records, in batches, in the background, see NewOTLPSink
+type OTLPSink struct {
+	config   OTLPConfig
+	resource otlpResource
+	ch       chan Entry
+	done     chan struct{}
+	dropped  uint64 // accessed atomically
+	gate     sinkGate
+}
+
+// NewOTLPSink returns an OTLPSink exporting the entries in the background with OTLP/HTTP and
+// the JSON encoding. The fields become the attributes of the log records, with the goroutine
+// id as "thread.id" and the caller as "code.filepath", "code.lineno" and "code.function". The
+// entries are buffered while the collector is unreachable, then dropped once the buffer is full.
+//
+//	otlp := easylogger.NewOTLPSink(easylogger.OTLPConfig{Endpoint: "http://otel-collector:4318/v1/logs", ServiceName: "billing"})
+//	l.AddSink(otlp)
+//	defer otlp.Close()
+func NewOTLPSink(config OTLPConfig) *OTLPSink {
+	if config.Endpoint == "" {
+		config.Endpoint = "http://127.0.0.1:4318/v1/logs"
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = filepath.Base(os.Args[0])
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 512
+	}
+	if config.BatchWait <= 0 {
+		config.BatchWait = time.Second
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.Retry == nil {
+		config.Retry = &DefaultRetryPolicy
+	}
+	config.Retry = withStatusRetry(*config.Retry)
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	attributes := map[string]string{"service.name": config.ServiceName}
+	if host, err := os.Hostname(); err == nil {
+		attributes["host.name"] = host
+	}
+	for k, v := range config.ResourceAttributes {
+		attributes[k] = v
+	}
+	s := &OTLPSink{config: config, ch: make(chan Entry, config.BufferSize), done: make(chan struct{})}
+	for _, k := range sortedKeys(attributes) {
+		s.resource.Attributes = append(s.resource.Attributes, otlpAttribute{k, otlpValue{StringValue: strPtr(attributes[k])}})
+	}
+	go s.run()
+	return s
+}
+
+// WriteEntry implements EntrySink.
+func (s *OTLPSink) WriteEntry(e Entry) error {
+	err := s.gate.send(func() bool {
+		select {
+		case s.ch <- e:
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return err
+}
+
+// Dropped returns the number of entries dropped because the buffer was full or they could
+// not be exported.
+func (s *OTLPSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close exports the buffered entries, then stops the sink.
+func (s *OTLPSink) Close() error {
+	s.gate.close(func() { close(s.ch) })
+	<-s.done
+	return nil
+}
+
+// run exports the entries in batches
+func (s *OTLPSink) run() {
+	defer close(s.done)
+	runBatches(s.ch, s.config.BatchSize, s.config.BatchWait, s.export)
+}
+
+// export sends batch to the collector
+func (s *OTLPSink) export(batch []Entry) {
+	body, err := s.request(batch)
+	if err == nil {
+		err = s.config.Retry.Do(context.Background(), func() error {
+			return postJSON(s.config.Client, s.config.Endpoint, s.config.Header, body)
+		})
+	}
+	if err != nil {
+		atomic.AddUint64(&s.dropped, uint64(len(batch)))
+		diagf(CodeSinkWrite, "%d entries not exported to %s: %v", len(batch), s.config.Endpoint, err)
+	}
+}
+
+// the JSON encoding of an OTLP ExportLogsServiceRequest, see
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/logs/v1/logs.proto
+type otlpRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber"`
+	SeverityText         string          `json:"severityText"`
+	Body                 otlpValue       `json:"body"`
+	Attributes           []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+// otlpValue is an AnyValue, only one of its fields is set. The 64-bit integers are strings in
+// the JSON encoding of protobuf.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// request renders batch as the JSON body of an export request
+func (s *OTLPSink) request(batch []Entry) ([]byte, error) {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	records := make([]otlpLogRecord, len(batch))
+	for i, e := range batch {
+		records[i] = otlpLogRecord{
+			TimeUnixNano:         strconv.FormatInt(e.Time.UnixNano(), 10),
+			ObservedTimeUnixNano: now,
+			SeverityNumber:       otlpSeverities[ErrorLevel],
+			SeverityText:         e.Level.String(),
+			Body:                 otlpValue{StringValue: strPtr(e.Message)},
+			Attributes:           otlpAttributes(e),
+		}
+		if e.Level >= TraceLevel && e.Level <= PanicLevel {
+			records[i].SeverityNumber = otlpSeverities[e.Level]
+		}
+	}
+	return json.Marshal(otlpRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  s.resource,
+		ScopeLogs: []otlpScopeLogs{{Scope: otlpScope{Name: "EasyLogger"}, LogRecords: records}},
+	}}})
+}
+
+// otlpAttributes returns the attributes of e: its fields, sorted by key, its goroutine id and
+// its caller
+func otlpAttributes(e Entry) []otlpAttribute {
+	attributes := make([]otlpAttribute, 0, len(e.Fields)+3)
+	for _, k := range e.Fields.keys() {
+		attributes = append(attributes, otlpAttribute{k, otlpAnyValue(e.Fields[k])})
+	}
+	if e.GID != 0 {
+		attributes = append(attributes, otlpAttribute{"thread.id", otlpAnyValue(e.GID)})
+	}
+	if i := strings.LastIndexByte(e.Caller, ':'); i > 0 {
+		attributes = append(attributes, otlpAttribute{"code.filepath", otlpAnyValue(e.Caller[:i])})
+		if line, err := strconv.Atoi(e.Caller[i+1:]); err == nil {
+			attributes = append(attributes, otlpAttribute{"code.lineno", otlpAnyValue(line)})
+		}
+	}
+	if e.Function != "" {
+		attributes = append(attributes, otlpAttribute{"code.function", otlpAnyValue(e.Function)})
+	}
+	return attributes
+}
+
+// otlpAnyValue converts a field value to an AnyValue, the values other than strings, booleans
+// and numbers being rendered as text
+func otlpAnyValue(v interface{}) otlpValue {
+	var i int64
+	switch v := v.(type) {
+	case string:
+		return otlpValue{StringValue: &v}
+	case bool:
+		return otlpValue{BoolValue: &v}
+	case float64:
+		return otlpValue{DoubleValue: &v}
+	case float32:
+		f := float64(v)
+		return otlpValue{DoubleValue: &f}
+	case int:
+		i = int64(v)
+	case int8:
+		i = int64(v)
+	case int16:
+		i = int64(v)
+	case int32:
+		i = int64(v)
+	case int64:
+		i = v
+	case uint8:
+		i = int64(v)
+	case uint16:
+		i = int64(v)
+	case uint32:
+		i = int64(v)
+	case uint:
+		return otlpUint(uint64(v))
+	case uint64:
+		return otlpUint(v)
+	default:
+		return otlpValue{StringValue: strPtr(fmt.Sprint(jsonValue(v)))}
+	}
+	return otlpValue{IntValue: strPtr(strconv.FormatInt(i, 10))}
+}
+
+// otlpUint converts an unsigned integer, as text if it overflows the int64 of an AnyValue
+func otlpUint(u uint64) otlpValue {
+	if u > math.MaxInt64 {
+		return otlpValue{StringValue: strPtr(strconv.FormatUint(u, 10))}
+	}
+	return otlpValue{IntValue: strPtr(strconv.FormatUint(u, 10))}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// sortedKeys returns the keys of m, sorted
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,52 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOTLPSink(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies <- b
+	}))
+	defer srv.Close()
+
+	s := NewOTLPSink(OTLPConfig{Endpoint: srv.URL, ServiceName: "billing",
+		ResourceAttributes: map[string]string{"host.name": "h1"}, BatchWait: time.Hour})
+	assert.Nil(t, s.WriteEntry(Entry{Time: time.Unix(1, 5), Level: WarnLevel, GID: 7, Caller: "main.go:12",
+		Message: "slow", Fields: Fields{"ms": 250, "ok": true, "user": "ann"}}))
+	assert.Nil(t, s.Close())
+
+	var req map[string]interface{}
+	assert.Nil(t, json.Unmarshal(<-bodies, &req))
+	rl := req["resourceLogs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "host.name", "value": map[string]interface{}{"stringValue": "h1"}},
+		map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": "billing"}},
+	}, rl["resource"].(map[string]interface{})["attributes"])
+
+	record := rl["scopeLogs"].([]interface{})[0].(map[string]interface{})["logRecords"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "1000000005", record["timeUnixNano"])
+	assert.EqualValues(t, 13, record["severityNumber"])
+	assert.Equal(t, "WARN", record["severityText"])
+	assert.Equal(t, map[string]interface{}{"stringValue": "slow"}, record["body"])
+	b, _ := json.Marshal(record["attributes"])
+	assert.Equal(t, `[{"key":"ms","value":{"intValue":"250"}},{"key":"ok","value":{"boolValue":true}},`+
+		`{"key":"user","value":{"stringValue":"ann"}},{"key":"thread.id","value":{"intValue":"7"}},`+
+		`{"key":"code.filepath","value":{"stringValue":"main.go"}},{"key":"code.lineno","value":{"intValue":"12"}}]`, string(b))
+}
+
+func TestOTLPAnyValue(t *testing.T) {
+	b, _ := json.Marshal([]otlpValue{otlpAnyValue(1.5), otlpAnyValue(uint64(math.MaxUint64)),
+		otlpAnyValue(time.Second)})
+	assert.Equal(t, `[{"doubleValue":1.5},{"stringValue":"18446744073709551615"},{"stringValue":"1s"}]`, string(b))
+}
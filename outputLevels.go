@@ -0,0 +1,75 @@
+package easylogger
+
+import (
+	"io"
+)
+
+// WithConsoleLevel sets the minimum level of the entries written to the console, e.g. WarnLevel
+// to keep the console quiet while the log files get everything. The level of the logger
+// still applies to all the outputs.
+func WithConsoleLevel(level Level) Option {
+	return withSetup(func(s *outputSetup) { s.consoleMin = level })
+}
+
+// levelWriter is an output only receiving the lines of min and the higher levels, see AddWriterLevel
+type levelWriter struct {
+	io.Writer
+	min Level
+}
+
+// AddWriterLevel adds w to the outputs of the logger like AddWriter, w only receives the lines
+// of min and the higher levels. It is removed with RemoveWriter(w).
+func (this *EasyLogger) AddWriterLevel(w io.Writer, min Level) {
+	this.AddWriter(&levelWriter{Writer: w, min: min})
+}
+
+// levelSink is an EntrySink only receiving the entries of min and the higher levels, see AddSinkLevel
+type levelSink struct {
+	EntrySink
+	min Level
+}
+
+func (s levelSink) WriteEntry(e Entry) error {
+	if e.Level < s.min {
+		return nil
+	}
+	return s.EntrySink.WriteEntry(e)
+}
+
+// AddSinkLevel adds s to the outputs of the logger like AddSink, s only receives the entries of
+// min and the higher levels. It is removed with RemoveSink(s).
+func (this *EasyLogger) AddSinkLevel(s EntrySink, min Level) {
+	this.AddSink(levelSink{EntrySink: s, min: min})
+}
+
+// writeLevel writes the line p of an entry of level to the writers of the sink accepting it
+func (s *sink) writeLevel(level Level, p []byte, lock bool) (int, error) {
+	if lock {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if mw, ok := s.w.(*multiWriter); ok {
+		return mw.writeLevel(level, p)
+	}
+	return s.w.Write(p)
+}
+
+// writeLevel writes p to the writers accepting level, it fails only if all of them fail
+func (t *multiWriter) writeLevel(level Level, p []byte) (int, error) {
+	var err error
+	written, failed := 0, 0
+	for _, w := range t.writers {
+		if lw, ok := w.(*levelWriter); ok && level < lw.min {
+			continue
+		}
+		written++
+		if _, e := w.Write(p); e != nil {
+			failed++
+			err = e
+		}
+	}
+	if failed > 0 && failed == written {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,61 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithConsoleLevel(t *testing.T) {
+	l := NewEasyLogger(WithConsole(true), WithConsoleLevel(WarnLevel))
+	assert.Equal(t, WarnLevel, l.console.min)
+
+	var out, console bytes.Buffer
+	l = &EasyLogger{out: newSink(&out), console: &sink{w: &console, min: WarnLevel}}
+	l.Info("file only")
+	l.Warn("both")
+	assert.Contains(t, out.String(), "file only")
+	assert.Contains(t, out.String(), "both")
+	assert.NotContains(t, console.String(), "file only")
+	assert.Contains(t, console.String(), "both")
+
+	// an entry left out of the console is still lost if the other outputs fail
+	l = &EasyLogger{out: newSink(failingWriter{}), console: &sink{w: &console, min: WarnLevel}}
+	assert.NotNil(t, l.output(InfoLevel, "lost"))
+}
+
+func TestEasyLogger_AddWriterLevel(t *testing.T) {
+	var out, warnings bytes.Buffer
+	l := &EasyLogger{out: newSink(&out)}
+	l.AddWriterLevel(&warnings, WarnLevel)
+	l.Info("info")
+	l.Error("error")
+	assert.Contains(t, out.String(), "info")
+	assert.Contains(t, out.String(), "error")
+	assert.NotContains(t, warnings.String(), "info")
+	assert.Contains(t, warnings.String(), "error")
+
+	assert.True(t, l.RemoveWriter(&warnings))
+	l.Error("removed")
+	assert.NotContains(t, warnings.String(), "removed")
+
+	// an entry left out of all the writers is not an error
+	l = &EasyLogger{}
+	l.AddWriterLevel(failingWriter{}, ErrorLevel)
+	assert.Nil(t, l.output(InfoLevel, "filtered"))
+}
+
+func TestEasyLogger_AddSinkLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	s := &entriesSink{}
+	l.AddSinkLevel(s, ErrorLevel)
+	l.Warn("warn")
+	l.Error("error")
+	assert.Equal(t, 1, len(s.entries))
+	assert.Equal(t, "error", s.entries[0].Message)
+
+	assert.True(t, l.RemoveSink(s))
+	l.Error("removed")
+	assert.Equal(t, 1, len(s.entries))
+}
@@ -0,0 +1,97 @@
+package easylogger
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotTextEntry is returned by ParseTextEntry for the lines not written by an EasyLogger
+	ErrNotTextEntry = errors.New("not an EasyLogger text entry")
+
+	ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	// the header written according to the log.Logger flags, see appendHeader
+	textHeader = regexp.MustCompile(`(?:(\d{4}/\d{2}/\d{2}) )?(?:(\d{2}:\d{2}:\d{2}(?:\.\d{6})?) )?(?:(\S+:\d+): )?$`)
+
+	// the caller added to the message by the TRACE and DEBUG methods
+	textCaller = regexp.MustCompile(`^\S+? (\S+\.go:\d+) `)
+)
+
+// ParseTextEntry parses a line written in the text Format, e.g. by tools reading old log files.
+// The time is read from the date and time written with the log.Ldate, log.Ltime and
+// log.Lmicroseconds flags, in the local time zone, it is zero if there are none. The caller
+// is read from the file and line written with log.Lshortfile or log.Llongfile, or from the
+// message of the TRACE and DEBUG entries. The fields are left in the message, as it can't be
+// told apart from them.
+func ParseTextEntry(line string) (Entry, error) {
+	line = strings.TrimSuffix(ansiEscape.ReplaceAllString(line, ""), "\n")
+
+	var e Entry
+	i := -1
+	for lvl, tag := range levelTags {
+		if j := strings.Index(line, tag+" "); j >= 0 && (i < 0 || j < i) {
+			i, e.Level = j, Level(lvl)
+		}
+	}
+	if i < 0 {
+		return Entry{}, ErrNotTextEntry
+	}
+
+	e.Message = line[i+len(levelTags[e.Level])+1:]
+	// the GID is left out with EnableGID(false)
+	if strings.HasPrefix(e.Message, "GID ") {
+		comma := strings.Index(e.Message, ", ")
+		if comma < 0 {
+			return Entry{}, ErrNotTextEntry
+		}
+		gid, err := strconv.ParseUint(e.Message[len("GID "):comma], 10, 64)
+		if err != nil {
+			return Entry{}, ErrNotTextEntry
+		}
+		e.GID = gid
+		e.Message = e.Message[comma+2:]
+	}
+
+	if m := textHeader.FindStringSubmatch(line[:i]); m != nil {
+		e.Time = parseHeaderTime(m[1], m[2])
+		e.Caller = m[3]
+	}
+	if e.Level <= DebugLevel {
+		if m := textCaller.FindStringSubmatchIndex(e.Message); m != nil {
+			if e.Caller == "" {
+				e.Caller = e.Message[m[2]:m[3]]
+			}
+			e.Message = e.Message[m[1]:]
+		}
+	}
+	return e, nil
+}
+
+// parseHeaderTime parses the date and time of a header, either may be empty
+func parseHeaderTime(date, clock string) time.Time {
+	if date == "" && clock == "" {
+		return time.Time{}
+	}
+	value, layout := "", ""
+	if date != "" {
+		value, layout = date, "2006/01/02"
+	}
+	if clock != "" {
+		if value != "" {
+			value, layout = value+" ", layout+" "
+		}
+		value, layout = value+clock, layout+"15:04:05"
+		if strings.Contains(clock, ".") {
+			layout += ".000000"
+		}
+	}
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
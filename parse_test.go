@@ -0,0 +1,63 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTextEntry(t *testing.T) {
+	e, err := ParseTextEntry("2021/09/01 15:25:23.123456 \x1b[32m[INFO ]\x1b[0m GID 7, hello world user=42\n")
+	assert.Nil(t, err)
+	assert.Equal(t, InfoLevel, e.Level)
+	assert.Equal(t, uint64(7), e.GID)
+	assert.Equal(t, "hello world user=42", e.Message)
+	assert.Equal(t, time.Date(2021, 9, 1, 15, 25, 23, 123456000, time.Local), e.Time)
+	assert.Equal(t, "", e.Caller)
+
+	e, err = ParseTextEntry("app 15:25:23 main.go:12: [ERROR] GID 1, boom")
+	assert.Nil(t, err)
+	assert.Equal(t, ErrorLevel, e.Level)
+	assert.Equal(t, "main.go:12", e.Caller)
+	assert.Equal(t, 15, e.Time.Hour())
+	assert.Equal(t, "boom", e.Message)
+
+	e, err = ParseTextEntry("[WARN ] no gid")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), e.GID)
+	assert.Equal(t, "no gid", e.Message)
+
+	_, err = ParseTextEntry("2021/09/01 some other line")
+	assert.Equal(t, ErrNotTextEntry, err)
+	_, err = ParseTextEntry("[INFO ] GID x, nope")
+	assert.Equal(t, ErrNotTextEntry, err)
+}
+
+func TestParseTextEntry_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), prefix: "svc ", flags: log.Ldate | log.Lmicroseconds}
+	l.Trace("traced")
+	l.Debugf("debug %d", 1)
+	l.With("k", "v").Warn("warned")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 3, len(lines))
+	var entries []Entry
+	for _, line := range lines {
+		e, err := ParseTextEntry(line)
+		assert.Nil(t, err)
+		assert.WithinDuration(t, time.Now(), e.Time, time.Minute)
+		entries = append(entries, e)
+	}
+	assert.Equal(t, TraceLevel, entries[0].Level)
+	assert.Equal(t, "traced", entries[0].Message)
+	assert.True(t, strings.HasPrefix(entries[0].Caller, "parse_test.go:"))
+	assert.Equal(t, "debug 1", entries[1].Message)
+	assert.True(t, strings.HasPrefix(entries[1].Caller, "parse_test.go:"))
+	assert.Equal(t, WarnLevel, entries[2].Level)
+	assert.Equal(t, "warned k=v", entries[2].Message)
+	assert.Equal(t, GetGID(), entries[2].GID)
+}
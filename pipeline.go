@@ -0,0 +1,396 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Every entry goes through the following stages, in this order:
+//
+//	sanitize  removes the terminal escape sequences, replaces the invalid UTF-8 and the control characters
+//	          other than '\t' and '\n' in the message and in the keys and string values of the fields,
+//	          and truncates the message, see SetMaxMessageLength
+//	redact    replaces the parts of the message matching the redaction patterns by "<redacted>"
+//	enrich    adds the goroutine id to the entry, except in single writer mode
+//	sample    drops the entry if the Sampler rejects it, if it repeats the last one, see SetDeduplication,
+//	          or if it is over the rate limit, see SetRateLimit
+//	policy    drops or annotates the entry if it misses a field required by the FieldPolicy
+//	hook      calls the Hooks of the level of the entry, which may add fields to it, see AddHook
+//	format    adds the Decoration of the level to the message, renders the entry and its fields as a
+//	          text, JSON or logfmt line depending on the Format, or with the Formatter, see
+//	          SetFormatter, and as a JSON line as well if there is a JSON sidecar
+//	fanout    writes the rendered entry to the captures, to the outputs, and to the routes of its
+//	          labels, or queues it in asynchronous mode
+//
+// sanitize, redact, enrich, sample, policy and hook can be disabled with SetStageEnabled,
+// format and fanout always run.
+const (
+	StageSanitize = "sanitize"
+	StageRedact   = "redact"
+	StageEnrich   = "enrich"
+	StageSample   = "sample"
+	StagePolicy   = "policy"
+	StageHook     = "hook"
+	StageFormat   = "format"
+	StageFanout   = "fanout"
+)
+
+const stageCount = 8
+
+// recentTimeFormat is the time layout of the text entries written to a RecentBuffer or a route
+const recentTimeFormat = "2006/01/02 15:04:05.000000 "
+
+// stages is the ordered pipeline
+var stages = [stageCount]struct {
+	name     string
+	optional bool
+	run      func(this *EasyLogger, r *record) bool
+}{
+	{StageSanitize, true, (*EasyLogger).sanitize},
+	{StageRedact, true, (*EasyLogger).redact},
+	{StageEnrich, true, (*EasyLogger).enrich},
+	{StageSample, true, (*EasyLogger).sample},
+	{StagePolicy, true, (*EasyLogger).policy},
+	{StageHook, true, (*EasyLogger).hook},
+	{StageFormat, false, (*EasyLogger).format},
+	{StageFanout, false, (*EasyLogger).fanout},
+}
+
+// Sampler decides whether an entry is kept by the sample stage
+type Sampler func(e Entry) bool
+
+// StageStats reports the timing of a pipeline stage
+type StageStats struct {
+	Name    string
+	Enabled bool
+	Count   uint64        // number of entries processed
+	Total   time.Duration // total time spent
+	Max     time.Duration // slowest entry
+}
+
+// stageState holds the switch and the counters of a stage, accessed atomically.
+// All fields are 64-bit so that they stay aligned on 32-bit platforms.
+type stageState struct {
+	count    uint64
+	nanos    uint64
+	maxNanos uint64
+	disabled uint64
+}
+
+// record is an entry on its way through the pipeline
+type record struct {
+	Entry
+	jsonFormat bool
+	plain      bool   // text is written as is, without the header, for the Formatter and logfmt
+	text       string // the text line with a plain level tag
+	colorText  string // the text line with a colored level tag, only set if needed
+	file       string // full path and line of the caller, only set if needed
+	fileLine   int
+	json       []byte
+	err        error
+	trace      *traceSpans // only set in self-trace mode, for the sampled records
+	summary    bool        // a summary of the entries left out, neither deduplicated nor limited
+}
+
+// line returns the rendered entry for the secondary outputs, with a timestamp for text lines
+func (r *record) line() []byte {
+	if r.jsonFormat {
+		return r.json
+	}
+	if r.plain {
+		return []byte(r.text)
+	}
+	return []byte(r.Time.Format(recentTimeFormat) + r.text)
+}
+
+// process runs the record through the enabled stages, stopping at the first one dropping it.
+func (this *EasyLogger) process(r *record) error {
+	if this.tracer != nil && this.tracer.sample() {
+		r.trace = &traceSpans{}
+		defer this.tracer.observe(r.trace)
+	}
+	for i := range stages {
+		st := &this.stages[i]
+		if atomic.LoadUint64(&st.disabled) == 1 {
+			continue
+		}
+		start := time.Now()
+		keep := stages[i].run(this, r)
+		d := time.Since(start)
+		st.observe(d)
+		if r.trace != nil {
+			r.trace.stages[i], r.trace.ran[i] = d, true
+		}
+		if !keep {
+			this.metrics.drop()
+			return nil
+		}
+	}
+	return r.err
+}
+
+func (st *stageState) observe(d time.Duration) {
+	atomic.AddUint64(&st.count, 1)
+	atomic.AddUint64(&st.nanos, uint64(d))
+	for {
+		max := atomic.LoadUint64(&st.maxNanos)
+		if uint64(d) <= max || atomic.CompareAndSwapUint64(&st.maxNanos, max, uint64(d)) {
+			return
+		}
+	}
+}
+
+// SetStageEnabled turns an optional pipeline stage on or off, it is safe to call at any time.
+func (this *EasyLogger) SetStageEnabled(name string, enabled bool) error {
+	for i := range stages {
+		if stages[i].name != name {
+			continue
+		}
+		if !stages[i].optional {
+			return fmt.Errorf("stage %s can't be disabled", name)
+		}
+		var disabled uint64
+		if !enabled {
+			disabled = 1
+		}
+		atomic.StoreUint64(&this.stages[i].disabled, disabled)
+		return nil
+	}
+	return fmt.Errorf("unknown stage %s", name)
+}
+
+// PipelineStats returns the timing of each pipeline stage, in pipeline order.
+func (this *EasyLogger) PipelineStats() []StageStats {
+	stats := make([]StageStats, len(stages))
+	for i := range stages {
+		st := &this.stages[i]
+		stats[i] = StageStats{
+			Name:    stages[i].name,
+			Enabled: atomic.LoadUint64(&st.disabled) == 0,
+			Count:   atomic.LoadUint64(&st.count),
+			Total:   time.Duration(atomic.LoadUint64(&st.nanos)),
+			Max:     time.Duration(atomic.LoadUint64(&st.maxNanos)),
+		}
+	}
+	return stats
+}
+
+// AddRedaction makes the redact stage replace the matches of pattern in messages by "<redacted>".
+// It should be called before the logger is used.
+func (this *EasyLogger) AddRedaction(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	this.redactions = append(this.redactions, re)
+	return nil
+}
+
+// SetSampler sets the Sampler of the sample stage, nil keeps every entry.
+// It should be called before the logger is used.
+func (this *EasyLogger) SetSampler(s Sampler) {
+	this.sampler = s
+}
+
+func (this *EasyLogger) sanitize(r *record) bool {
+	r.Message = sanitizeMessage(r.Message, int(atomic.LoadInt32(&this.maxMessage)))
+	r.Fields = sanitizeFields(r.Fields)
+	return true
+}
+
+func (this *EasyLogger) redact(r *record) bool {
+	for _, re := range this.redactions {
+		r.Message = re.ReplaceAllString(r.Message, redacted)
+	}
+	return true
+}
+
+func (this *EasyLogger) enrich(r *record) bool {
+	if this.isSingleWriter() || this.noGID {
+		return true
+	}
+	r.GID = GetGID()
+	return true
+}
+
+func (this *EasyLogger) sample(r *record) bool {
+	return (this.sampler == nil || this.sampler(r.Entry)) && this.deduplicate(r) && this.limit(r)
+}
+
+func (this *EasyLogger) format(r *record) bool {
+	this.decorate(r)
+	format := this.GetFormat()
+	r.jsonFormat = format == JSONFormat
+	if format == LogfmtFormat {
+		r.text, r.plain = string(r.MarshalLogfmt())+"\n", true
+		if this.jsonOut == nil {
+			return true
+		}
+	} else if !r.jsonFormat && this.formatter != nil {
+		text := string(this.formatter.Format(r.Entry))
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if r.Stack != "" {
+			text += indentStack(r.Stack)
+		}
+		r.text, r.plain = text, true
+		if this.jsonOut == nil {
+			return true
+		}
+	} else if !r.jsonFormat {
+		msg := r.Message
+		if r.Function != "" {
+			msg = r.Caller + " " + r.Function + "() " + msg
+		}
+		body := " " + msg
+		if !this.noGID {
+			body = fmt.Sprintf(" GID %d, %s", r.GID, msg)
+		}
+		if len(r.Fields) > 0 {
+			body += " " + r.Fields.String()
+		}
+		body += "\n"
+		if r.Stack != "" {
+			body += indentStack(r.Stack)
+		}
+		r.text = r.Level.tag() + body
+		if this.colorOut() || (this.console != nil && this.colorConsole()) {
+			r.colorText = r.Level.color().Sprint(r.Level.tag()) + body
+		}
+		if this.jsonOut == nil {
+			return true
+		}
+	}
+	b, err := json.Marshal(r.Entry)
+	if err == nil && this.encrypter != nil {
+		b, err = this.encrypter.encryptJSON(b)
+	}
+	if err != nil {
+		r.err = err
+		return true
+	}
+	r.json = append(b, '\n')
+	return true
+}
+
+func (this *EasyLogger) fanout(r *record) bool {
+	this.captures.write(r)
+	if r.Level < this.GetLevel() {
+		// only logged for the captures
+		return true
+	}
+	if this.async != nil && this.async.enqueue(this, r) {
+		return true
+	}
+	this.write(r)
+	return true
+}
+
+// writeLine writes the rendered record to w, with a colored level tag if color is set
+func (this *EasyLogger) writeLine(w io.Writer, r *record, color bool) error {
+	_, err := w.Write(this.renderLine(r, color))
+	return err
+}
+
+// writeLineTo writes the rendered record to the writers of s accepting its level, without
+// locking s in single writer mode
+func (this *EasyLogger) writeLineTo(s *sink, r *record, color bool) (int, error) {
+	return s.writeLevel(r.Level, this.renderLine(r, color), !this.isSingleWriter())
+}
+
+// renderLine returns the line of the record, with a colored level tag if color is set
+func (this *EasyLogger) renderLine(r *record, color bool) []byte {
+	if r.jsonFormat {
+		return r.json
+	}
+	if r.plain {
+		return []byte(r.text)
+	}
+	text := r.text
+	if color && r.colorText != "" {
+		text = r.colorText
+	}
+	buf := appendHeader(make([]byte, 0, 64+len(text)), this.prefix, this.flags, r.Time, r.file, r.fileLine)
+	return append(buf, text...)
+}
+
+// write writes the rendered record to the outputs
+func (this *EasyLogger) write(r *record) {
+	if this.isSingleWriter() {
+		defer this.enterSingleWriter()()
+	}
+	start := time.Now()
+	written := 0
+	if !r.jsonFormat || r.json != nil {
+		written, r.err = this.writeLineTo(this.out, r, this.colorOut())
+		if this.console != nil && r.Level >= this.console.min {
+			// like the other outputs, the entry is lost only if all fail
+			if _, errConsole := this.writeLineTo(this.console, r, this.colorConsole()); errConsole == nil {
+				r.err = nil
+			} else if r.err != nil {
+				r.err = errConsole
+			}
+		}
+	}
+	r.trace.since(fanoutOutput, start)
+
+	start = time.Now()
+	errJSON := errors.New("no JSON output")
+	if r.json != nil && this.jsonOut != nil {
+		var n int
+		n, errJSON = this.jsonOut.Write(r.json)
+		written += n
+	}
+	if r.err != nil && errJSON != nil && (!r.jsonFormat || r.json != nil) &&
+		this.GetWriteFailurePolicy() == FailureBlock {
+		diagf(CodeWrite, "%s entry not written, retrying: %v", r.Level, r.err)
+		written, r.err = this.retryWrite(r)
+	}
+	r.trace.since(fanoutJSON, start)
+
+	if r.Level >= ErrorLevel && len(this.buffers) > 0 {
+		if err := this.flushBuffers(); err != nil {
+			diagf(CodeWrite, "buffered entries not written: %v", err)
+		}
+	}
+	if this.syncer != nil && this.syncer.due(r.Level) {
+		if err := this.syncFiles(); err != nil {
+			diagf(CodeWrite, "log files not synced: %v", err)
+		}
+	}
+
+	start = time.Now()
+	if this.recent != nil {
+		this.recent.Write(r.line())
+	}
+	r.trace.since(fanoutRecent, start)
+
+	start = time.Now()
+	for _, rt := range this.routes {
+		if r.HasLabel(rt.label) {
+			rt.w.Write(r.line())
+		}
+	}
+	r.trace.since(fanoutRoutes, start)
+
+	start = time.Now()
+	this.sinks.write(r.Entry)
+	r.trace.since(fanoutSinks, start)
+
+	if r.err != nil && errJSON != nil {
+		this.health.failed(r.err)
+		diagf(CodeWrite, "%s entry not written: %v", r.Level, r.err)
+		this.metrics.failed()
+		this.writeFailed(r)
+	} else {
+		this.health.succeeded()
+		this.metrics.written(r.Level, written)
+	}
+}
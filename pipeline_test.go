@@ -0,0 +1,45 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_Pipeline(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	assert.Nil(t, l.AddRedaction(`password=\S+`))
+	assert.NotNil(t, l.AddRedaction(`(`))
+	l.SetSampler(func(e Entry) bool {
+		return !strings.Contains(e.Message, "noise")
+	})
+
+	l.Info("login password=hunter2\x1b[31m")
+	l.Info("noise")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "login "+redacted)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "\x1b[31m")
+	assert.NotContains(t, buf.String(), "noise")
+
+	stats := l.PipelineStats()
+	assert.Equal(t, stageCount, len(stats))
+	assert.Equal(t, StageSanitize, stats[0].Name)
+	assert.Equal(t, uint64(2), stats[3].Count) // sample sees both entries
+	assert.Equal(t, uint64(1), stats[stageCount-1].Count) // fanout only the kept one
+	assert.Equal(t, StageFanout, stats[stageCount-1].Name)
+
+	// disabled stages are skipped
+	buf.Reset()
+	assert.Nil(t, l.SetStageEnabled(StageSample, false))
+	assert.Nil(t, l.SetStageEnabled(StageEnrich, false))
+	l.Info("noise")
+	assert.Contains(t, buf.String(), "GID 0, noise")
+	assert.False(t, l.PipelineStats()[3].Enabled)
+	assert.Equal(t, uint64(2), l.PipelineStats()[3].Count)
+
+	assert.NotNil(t, l.SetStageEnabled(StageFormat, false))
+	assert.NotNil(t, l.SetStageEnabled("unknown", false))
+}
@@ -0,0 +1,63 @@
+package easylogger
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// MissingFieldsField lists the required fields missing from an entry annotated by the FieldPolicy
+const MissingFieldsField = "missing_fields"
+
+// FieldPolicy lists the fields every entry must have, e.g. "service", "env" and "version"
+type FieldPolicy struct {
+	Required []string
+
+	// Reject drops the entries missing a required field, otherwise they are logged with a
+	// "missing_fields" field listing them.
+	Reject bool
+}
+
+var (
+	fieldPolicy      atomic.Value // *FieldPolicy, shared by all the loggers
+	policyViolations uint64       // accessed atomically
+)
+
+// SetFieldPolicy sets the FieldPolicy of all the loggers of the process, so that a platform
+// team can enforce its logging standards from a single place. nil removes it. It is safe to
+// call at any time.
+func SetFieldPolicy(p *FieldPolicy) {
+	fieldPolicy.Store(p)
+}
+
+// PolicyViolations returns the number of entries which missed a field required by the FieldPolicy.
+func PolicyViolations() uint64 {
+	return atomic.LoadUint64(&policyViolations)
+}
+
+func (this *EasyLogger) policy(r *record) bool {
+	p, _ := fieldPolicy.Load().(*FieldPolicy)
+	if p == nil {
+		return true
+	}
+	var missing []string
+	for _, k := range p.Required {
+		if _, ok := r.Fields[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+	atomic.AddUint64(&policyViolations, 1)
+	if p.Reject {
+		diagf(CodePolicy, "%s entry rejected, missing fields %s", r.Level, strings.Join(missing, ","))
+		return false
+	}
+	fields := make(Fields, len(r.Fields)+1)
+	for k, v := range r.Fields {
+		fields[k] = v
+	}
+	fields[MissingFieldsField] = strings.Join(missing, ",")
+	r.Fields = fields
+	return true
+}
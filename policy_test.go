@@ -0,0 +1,35 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSetFieldPolicy(t *testing.T) {
+	defer SetFieldPolicy(nil)
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	svc := l.With("service", "api", "env", "prod")
+	before := PolicyViolations()
+
+	SetFieldPolicy(&FieldPolicy{Required: []string{"service", "env", "version"}})
+	l.Info("bare")
+	svc.Info("partial")
+	svc.With("version", "1.2").Info("complete")
+	assert.Equal(t, before+2, PolicyViolations())
+
+	SetFieldPolicy(&FieldPolicy{Required: []string{"service"}, Reject: true})
+	l.Info("rejected")
+	svc.Info("accepted")
+	assert.Equal(t, before+3, PolicyViolations())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.True(t, strings.HasSuffix(lines[0], "bare missing_fields=service,env,version"))
+	assert.True(t, strings.HasSuffix(lines[1], "partial env=prod missing_fields=version service=api"))
+	assert.NotContains(t, lines[2], MissingFieldsField)
+	assert.Contains(t, lines[3], "accepted")
+	assert.Equal(t, 2, len(svc.fields))
+}
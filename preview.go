@@ -0,0 +1,80 @@
+package easylogger
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatterConfig is the part of the configuration of a logger deciding how its lines look,
+// see PreviewFormat
+type FormatterConfig struct {
+	Format      Format
+	Prefix      string
+	Flags       int                  // log.Logger flags of the text lines
+	Color       bool                 // whether the level tags are colored
+	Decorations map[Level]Decoration // Decoration of the messages of each level
+	Formatter   Formatter            // renders the text lines in place of the default layout, see SetFormatter
+}
+
+// FormatterConfig returns the current FormatterConfig of the logger, Color being whether its
+// log files get colored level tags.
+func (this *EasyLogger) FormatterConfig() FormatterConfig {
+	c := FormatterConfig{
+		Format:    this.GetFormat(),
+		Prefix:    this.prefix,
+		Flags:     this.flags,
+		Color:     this.colorOut(),
+		Formatter: this.formatter,
+	}
+	if ds, _ := this.decorations.Load().(*decorations); ds != nil {
+		for l, d := range ds {
+			if d != (Decoration{}) {
+				if c.Decorations == nil {
+					c.Decorations = make(map[Level]Decoration)
+				}
+				c.Decorations[Level(l)] = d
+			}
+		}
+	}
+	return c
+}
+
+// PreviewFormat renders sample as a logger configured with cfg would write it, without logging
+// anything, e.g. to check a configuration change against the parsers downstream before applying
+// it. The caller of sample, "file:line", is used by the file flags and the JSON format.
+func PreviewFormat(cfg FormatterConfig, sample Entry) (string, error) {
+	if cfg.Format < TextFormat || cfg.Format > LogfmtFormat {
+		return "", fmt.Errorf("invalid format %s", cfg.Format)
+	}
+	mode := ColorNever
+	if cfg.Color {
+		mode = ColorAlways
+	}
+	l := &EasyLogger{prefix: cfg.Prefix, flags: cfg.Flags, colorMode: int32(mode), lineFormat: int32(cfg.Format),
+		formatter: cfg.Formatter}
+	for level, d := range cfg.Decorations {
+		if level < TraceLevel || level > PanicLevel {
+			return "", fmt.Errorf("invalid decorated level %s", level)
+		}
+		l.SetDecoration(level, d)
+	}
+
+	r := &record{Entry: sample}
+	r.file = "???"
+	if i := strings.LastIndexByte(sample.Caller, ':'); i > 0 {
+		if line, err := strconv.Atoi(sample.Caller[i+1:]); err == nil {
+			r.file, r.fileLine = sample.Caller[:i], line
+		}
+	}
+	l.format(r)
+	if r.err != nil {
+		return "", r.err
+	}
+	var buf bytes.Buffer
+	if err := l.writeLine(&buf, r, cfg.Color); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
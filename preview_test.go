@@ -0,0 +1,45 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestPreviewFormat(t *testing.T) {
+	sample := Entry{Time: time.Date(2021, 9, 1, 15, 25, 23, 123456000, time.UTC), Level: WarnLevel, GID: 7,
+		Caller: "main.go:12", Message: "disk low", Fields: Fields{"free": "1G"}}
+
+	s, err := PreviewFormat(FormatterConfig{Prefix: "app ", Flags: log.Ldate | log.Ltime | log.LUTC | log.Lshortfile,
+		Decorations: map[Level]Decoration{WarnLevel: {Suffix: " [page]"}}}, sample)
+	assert.Nil(t, err)
+	assert.Equal(t, "app 2021/09/01 15:25:23 main.go:12: "+WARN+" GID 7, disk low [page] free=1G\n", s)
+
+	s, err = PreviewFormat(FormatterConfig{Format: JSONFormat}, sample)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"time":"2021-09-01T15:25:23.123456Z","level":"WARN","gid":7,"caller":"main.go:12","msg":"disk low","free":"1G"}`+"\n", s)
+
+	// the preview follows the logger
+	l := &EasyLogger{prefix: "app ", flags: log.Lmsgprefix}
+	l.SetDecoration(ErrorLevel, Decoration{Prefix: "!! "})
+	cfg := l.FormatterConfig()
+	assert.Equal(t, map[Level]Decoration{ErrorLevel: {Prefix: "!! "}}, cfg.Decorations)
+	cfg.Color = false
+	sample.Level = ErrorLevel
+	s, err = PreviewFormat(cfg, sample)
+	assert.Nil(t, err)
+	assert.Equal(t, "app "+ERROR+" GID 7, !! disk low free=1G\n", s)
+
+	f, err := NewTemplateFormatter("{level} {caller} {msg}")
+	assert.Nil(t, err)
+	l.SetFormatter(f)
+	s, err = PreviewFormat(l.FormatterConfig(), sample)
+	assert.Nil(t, err)
+	assert.Equal(t, "ERROR main.go:12 !! disk low\n", s)
+
+	_, err = PreviewFormat(FormatterConfig{Format: Format(9)}, sample)
+	assert.NotNil(t, err)
+	_, err = PreviewFormat(FormatterConfig{Decorations: map[Level]Decoration{Level(9): {}}}, sample)
+	assert.NotNil(t, err)
+}
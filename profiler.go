@@ -0,0 +1,125 @@
+package easylogger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// ProfilerConfig configures the profiles captured when the error rate gets high
+type ProfilerConfig struct {
+	// Threshold is the number of ERROR and FATAL entries within Window triggering a capture.
+	Threshold int
+
+	// Window is the length of the window the entries are counted in.
+	Window time.Duration
+
+	// CPUDuration is the length of the CPU profile, zero means only the goroutine profile.
+	CPUDuration time.Duration
+
+	// Cooldown is the minimum time between two captures.
+	Cooldown time.Duration
+
+	// Directory is where the profiles are written, the default is the log directory.
+	Directory string
+}
+
+// errorProfiler counts the errors and captures the profiles
+type errorProfiler struct {
+	ProfilerConfig
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+	lastCapture time.Time
+	capturing   bool
+}
+
+// EnableErrorProfiler makes the logger capture a goroutine profile, and optionally a short
+// CPU profile, when the rate of ERROR and FATAL entries crosses the threshold, to help
+// diagnose error storms caused by resource exhaustion. The profiles are written as
+// "profile-<time>.goroutine.pprof" and "profile-<time>.cpu.pprof", and a WARN entry
+// referencing them is logged. It should be called before the logger is used.
+func (this *EasyLogger) EnableErrorProfiler(cfg ProfilerConfig) {
+	if cfg.Directory == "" {
+		cfg.Directory = this.dir
+	}
+	if cfg.Directory == "" {
+		cfg.Directory = os.TempDir()
+	}
+	this.profiler = &errorProfiler{ProfilerConfig: cfg}
+}
+
+// observe counts an error entry, starting a capture if the threshold is crossed
+func (p *errorProfiler) observe(l *EasyLogger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(p.windowStart) > p.Window {
+		p.windowStart = now
+		p.count = 0
+	}
+	p.count++
+	if p.count < p.Threshold || p.capturing || (!p.lastCapture.IsZero() && now.Sub(p.lastCapture) < p.Cooldown) {
+		return
+	}
+	p.capturing = true
+	p.lastCapture = now
+	go p.capture(l, p.count, now)
+}
+
+func (p *errorProfiler) capture(l *EasyLogger, count int, now time.Time) {
+	defer func() {
+		p.mu.Lock()
+		p.capturing = false
+		p.mu.Unlock()
+	}()
+
+	base := filepath.Join(p.Directory, "profile-"+now.Format("20060102T150405.000"))
+	var written []string
+
+	name := base + ".goroutine.pprof"
+	if err := writeProfile(name, func(f *os.File) error {
+		return pprof.Lookup("goroutine").WriteTo(f, 0)
+	}); err != nil {
+		l.Warnf("error rate threshold crossed, failed to write goroutine profile: %s", err)
+	} else {
+		written = append(written, name)
+	}
+
+	if p.CPUDuration > 0 {
+		name = base + ".cpu.pprof"
+		if err := writeProfile(name, func(f *os.File) error {
+			if err := pprof.StartCPUProfile(f); err != nil {
+				return err
+			}
+			time.Sleep(p.CPUDuration)
+			pprof.StopCPUProfile()
+			return nil
+		}); err != nil {
+			l.Warnf("error rate threshold crossed, failed to write CPU profile: %s", err)
+		} else {
+			written = append(written, name)
+		}
+	}
+
+	if len(written) > 0 {
+		l.Warnf("error rate threshold crossed: %d errors within %s, profiles written to %v", count, p.Window, written)
+	}
+}
+
+func writeProfile(name string, write func(f *os.File) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		f.Close()
+		os.Remove(name)
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,65 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestEasyLogger_EnableErrorProfiler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	var buf syncBuffer
+	l := &EasyLogger{out: newSink(&buf), dir: dir}
+	l.EnableErrorProfiler(ProfilerConfig{
+		Threshold:   3,
+		Window:      time.Minute,
+		CPUDuration: 10 * time.Millisecond,
+		Cooldown:    time.Hour,
+	})
+
+	l.Error("one")
+	l.Error("two")
+	l.Warn("not counted")
+	time.Sleep(50 * time.Millisecond)
+	assert.NotContains(t, buf.String(), "threshold crossed")
+
+	l.Error("three")
+	l.Error("four")
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buf.String(), "threshold crossed") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Contains(t, buf.String(), "threshold crossed: 3 errors")
+
+	goroutine, _ := filepath.Glob(filepath.Join(dir, "profile-*.goroutine.pprof"))
+	assert.Equal(t, 1, len(goroutine))
+	cpu, _ := filepath.Glob(filepath.Join(dir, "profile-*.cpu.pprof"))
+	assert.Equal(t, 1, len(cpu))
+}
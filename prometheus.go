@@ -0,0 +1,78 @@
+// +build prometheus
+
+package easylogger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusCollector is a prometheus.Collector exporting the Stats of an EasyLogger, see
+// NewPrometheusCollector
+type PrometheusCollector struct {
+	el          *EasyLogger
+	entries     *prometheus.Desc
+	bytes       *prometheus.Desc
+	errors      *prometheus.Desc
+	dropped     *prometheus.Desc
+	queueDepth  *prometheus.Desc
+	rotations   *prometheus.Desc
+	compression *prometheus.Desc
+	fileSize    *prometheus.Desc
+	openFiles   *prometheus.Desc
+	maxOpen     *prometheus.Desc
+}
+
+// NewPrometheusCollector returns a prometheus.Collector exporting the Stats of the logger and
+// its clones, with labels the constant labels of the metrics, e.g. {"logger": "audit"} to tell
+// several loggers apart. Build with -tags prometheus:
+//
+//	prometheus.MustRegister(el.NewPrometheusCollector(nil))
+func (this *EasyLogger) NewPrometheusCollector(labels map[string]string) *PrometheusCollector {
+	desc := func(name, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("easylogger", "", name), help, variableLabels, labels)
+	}
+	return &PrometheusCollector{
+		el:          this,
+		entries:     desc("entries_total", "Entries written, by level.", "level"),
+		bytes:       desc("written_bytes_total", "Bytes written to the log files and the JSON output."),
+		errors:      desc("write_errors_total", "Entries lost because all the outputs failed."),
+		dropped:     desc("dropped_entries_total", "Entries dropped by the sampling, the rate limit, the field policy or a full queue."),
+		queueDepth:  desc("queue_depth", "Entries waiting in the async queue."),
+		rotations:   desc("rotations_total", "Rotations of the time rotating log files."),
+		compression: desc("compression_seconds_total", "Time spent compressing the rotated files."),
+		fileSize:    desc("file_size_bytes", "Size of the current log file."),
+		openFiles:   desc("open_files", "Log files open by all the loggers."),
+		maxOpen:     desc("max_open_files", "Limit of the open log files, 0 if none."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.bytes
+	ch <- c.errors
+	ch <- c.dropped
+	ch <- c.queueDepth
+	ch <- c.rotations
+	ch <- c.compression
+	ch <- c.fileSize
+	ch <- c.openFiles
+	ch <- c.maxOpen
+}
+
+// Collect implements prometheus.Collector.
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.el.Stats()
+	for level := TraceLevel; level <= PanicLevel; level++ {
+		ch <- prometheus.MustNewConstMetric(c.entries, prometheus.CounterValue, float64(stats.Entries[level]), level.String())
+	}
+	ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.CounterValue, float64(stats.Bytes))
+	ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(stats.Errors))
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(stats.Dropped))
+	ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(stats.QueueDepth))
+	ch <- prometheus.MustNewConstMetric(c.rotations, prometheus.CounterValue, float64(stats.Rotations))
+	ch <- prometheus.MustNewConstMetric(c.compression, prometheus.CounterValue, stats.Compression.Duration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.fileSize, prometheus.GaugeValue, float64(stats.FileSize))
+	ch <- prometheus.MustNewConstMetric(c.openFiles, prometheus.GaugeValue, float64(stats.OpenFiles))
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenFiles))
+}
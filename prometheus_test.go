@@ -0,0 +1,27 @@
+// +build prometheus
+
+package easylogger
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestEasyLogger_NewPrometheusCollector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir))
+	defer l.Close()
+	l.Info("info")
+	c := l.NewPrometheusCollector(map[string]string{"logger": "app"})
+	assert.Equal(t, 7, testutil.CollectAndCount(c, "easylogger_entries_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "easylogger_written_bytes_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "easylogger_file_size_bytes"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "easylogger_open_files"))
+	assert.Equal(t, 16, testutil.CollectAndCount(c))
+}
@@ -0,0 +1,186 @@
+package easylogger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DroppedField is the field of the number of entries dropped in a rate limit summary
+const DroppedField = "dropped"
+
+// RateLimit is a token bucket: Rate entries per second on average, in bursts of up to Burst
+// entries. A zero Rate means no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitConfig configures the rate limiting of the entries, see SetRateLimit
+type RateLimitConfig struct {
+	// Global limits all the entries.
+	Global RateLimit
+
+	// Levels limit the entries of their level, on top of Global.
+	Levels map[Level]RateLimit
+
+	// SummaryInterval is the delay after a first drop before the summary is logged, the
+	// default is 10 seconds.
+	SummaryInterval time.Duration
+}
+
+// tokenBucket is a RateLimit in use, its owner locks it
+type tokenBucket struct {
+	RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{RateLimit: limit, tokens: float64(limit.Burst)}
+}
+
+// fill adds the tokens earned since the last call, it reports whether a token is available
+func (b *tokenBucket) fill(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.Rate
+		if b.tokens > float64(b.Burst) {
+			b.tokens = float64(b.Burst)
+		}
+	}
+	b.last = now
+	return b.tokens >= 1
+}
+
+func (b *tokenBucket) take() {
+	if b != nil {
+		b.tokens--
+	}
+}
+
+// rateLimiter holds the buckets and the drop counters of a logger and its clones
+type rateLimiter struct {
+	interval  time.Duration
+	summarize func() // logs the summary with the logger of SetRateLimit
+
+	mu      sync.Mutex
+	global  *tokenBucket
+	levels  [PanicLevel + 1]*tokenBucket
+	dropped [PanicLevel + 1]uint64
+	pending uint64 // dropped since the last summary
+}
+
+// SetRateLimit limits the rate of the entries written by the logger and its clones, protecting
+// the service and the disk during a log storm. The entries over the limits are dropped by the
+// sample stage, and their number is logged in a WARN summary, with a "dropped" field, once
+// SummaryInterval has elapsed since the first drop:
+//
+//	l.SetRateLimit(easylogger.RateLimitConfig{
+//		Global: easylogger.RateLimit{Rate: 1000, Burst: 2000},
+//		Levels: map[easylogger.Level]easylogger.RateLimit{easylogger.DebugLevel: {Rate: 100, Burst: 100}},
+//	})
+//
+// It should be called before the logger is used.
+func (this *EasyLogger) SetRateLimit(config RateLimitConfig) {
+	if config.SummaryInterval <= 0 {
+		config.SummaryInterval = 10 * time.Second
+	}
+	rl := &rateLimiter{interval: config.SummaryInterval, summarize: this.summarizeDrops, global: newTokenBucket(config.Global)}
+	for level, limit := range config.Levels {
+		if level < TraceLevel || level > PanicLevel {
+			this.misuse("invalid level %s", level)
+			continue
+		}
+		rl.levels[level] = newTokenBucket(limit)
+	}
+	this.limiter = rl
+}
+
+// GetRateLimitStats returns the number of entries dropped by the rate limit by level.
+func (this *EasyLogger) GetRateLimitStats() map[Level]uint64 {
+	stats := make(map[Level]uint64)
+	if this.limiter == nil {
+		return stats
+	}
+	this.limiter.mu.Lock()
+	defer this.limiter.mu.Unlock()
+	for level, n := range this.limiter.dropped {
+		if n > 0 {
+			stats[Level(level)] = n
+		}
+	}
+	return stats
+}
+
+// allow reports whether an entry of level can be written at now, and whether it is the first
+// drop since the last summary
+func (rl *rateLimiter) allow(level Level, now time.Time) (bool, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	var lb *tokenBucket
+	if level >= TraceLevel && level <= PanicLevel {
+		lb = rl.levels[level]
+	}
+	if okLevel, okGlobal := lb.fill(now), rl.global.fill(now); okLevel && okGlobal {
+		lb.take()
+		rl.global.take()
+		return true, false
+	}
+	if level >= TraceLevel && level <= PanicLevel {
+		rl.dropped[level]++
+	}
+	rl.pending++
+	return false, rl.pending == 1
+}
+
+// limit drops the record if it is over the rate limit, scheduling the summary on the first drop
+func (this *EasyLogger) limit(r *record) bool {
+	if this.limiter == nil || r.summary || r.Level < this.GetLevel() {
+		return true
+	}
+	ok, first := this.limiter.allow(r.Level, time.Now())
+	if first {
+		time.AfterFunc(this.limiter.interval, this.limiter.summarize)
+	}
+	return ok
+}
+
+// summarizeDrops logs the number of entries dropped since the last summary
+func (this *EasyLogger) summarizeDrops() {
+	this.limiter.mu.Lock()
+	n := this.limiter.pending
+	this.limiter.pending = 0
+	this.limiter.mu.Unlock()
+
+	level := WarnLevel
+	if l := this.GetLevel(); l > level {
+		level = l
+	}
+	this.logSummary(level, fmt.Sprintf("%d entries dropped by the rate limit", n), Fields{DroppedField: n})
+}
+
+// logSummary logs an entry reporting on the entries left out by the logger, the summaries are
+// neither deduplicated nor rate limited
+func (this *EasyLogger) logSummary(level Level, msg string, fields Fields) {
+	now, _ := this.now()
+	r := &record{Entry: Entry{
+		Time:    now,
+		Level:   level,
+		Message: msg,
+		Fields:  fields,
+		Labels:  this.labels,
+	}, summary: true}
+	if this.needsCaller() {
+		r.file = "???"
+	}
+	this.process(r)
+}
@@ -0,0 +1,34 @@
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_SetRateLimit(t *testing.T) {
+	var buf syncBuffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetRateLimit(RateLimitConfig{
+		Global:          RateLimit{Rate: 0.001, Burst: 5},
+		Levels:          map[Level]RateLimit{DebugLevel: {Rate: 0.001, Burst: 2}},
+		SummaryInterval: 50 * time.Millisecond,
+	})
+
+	for i := 0; i < 4; i++ {
+		l.Debug("debug")
+	}
+	for i := 0; i < 6; i++ {
+		l.With("i", i).Error("error") // the clones share the limits
+	}
+	out := buf.String()
+	assert.Equal(t, 2, strings.Count(out, "debug"))
+	assert.Equal(t, 3, strings.Count(out, "error"))
+	assert.Equal(t, map[Level]uint64{DebugLevel: 2, ErrorLevel: 3}, l.GetRateLimitStats())
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Contains(t, buf.String(), "WARN")
+	assert.Contains(t, buf.String(), "5 entries dropped by the rate limit dropped=5")
+	assert.Equal(t, 1, strings.Count(buf.String(), "dropped by the rate limit"))
+}
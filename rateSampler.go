@@ -0,0 +1,95 @@
+package easylogger
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rateSamplerBuckets is the number of counters of a level, the messages sharing a counter are
+// sampled together
+const rateSamplerBuckets = 4096
+
+// SamplingRule caps the identical entries of a level logged during a tick: the First ones are
+// kept, then one in Thereafter, none if Thereafter is zero.
+type SamplingRule struct {
+	First      int
+	Thereafter int
+}
+
+// RateSampler is a Sampler capping the identical entries, those having the same level and
+// message, logged per tick, see NewRateSampler
+type RateSampler struct {
+	dropped uint64 // accessed atomically, first to stay aligned on 32-bit platforms
+	tick    time.Duration
+	now     func() time.Time
+	rules   [PanicLevel + 1]*SamplingRule
+
+	mu          sync.Mutex
+	counts      [PanicLevel + 1][]uint64
+	windowStart time.Time
+}
+
+// NewRateSampler returns a RateSampler applying the rules of the levels during every tick, one
+// second if tick is zero, the entries of the levels without rule being kept, e.g. to keep a
+// tight error loop from filling the disk:
+//
+//	s := easylogger.NewRateSampler(time.Second, map[easylogger.Level]easylogger.SamplingRule{
+//		easylogger.ErrorLevel: {First: 100, Thereafter: 100},
+//	})
+//	l.SetSampler(s.Sample)
+func NewRateSampler(tick time.Duration, rules map[Level]SamplingRule) *RateSampler {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	s := &RateSampler{tick: tick, now: time.Now}
+	for level, rule := range rules {
+		if level < TraceLevel || level > PanicLevel {
+			continue
+		}
+		rule := rule
+		s.rules[level] = &rule
+		s.counts[level] = make([]uint64, rateSamplerBuckets)
+	}
+	return s
+}
+
+// Sample is the Sampler, it reports whether e is kept.
+func (s *RateSampler) Sample(e Entry) bool {
+	if e.Level < TraceLevel || e.Level > PanicLevel || s.rules[e.Level] == nil {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(e.Message))
+
+	s.mu.Lock()
+	if now := s.now(); now.Sub(s.windowStart) >= s.tick {
+		for _, counts := range s.counts {
+			for i := range counts {
+				counts[i] = 0
+			}
+		}
+		s.windowStart = now
+	}
+	counts := s.counts[e.Level]
+	bucket := h.Sum32() % rateSamplerBuckets
+	counts[bucket]++
+	n := counts[bucket]
+	s.mu.Unlock()
+
+	rule := s.rules[e.Level]
+	if n <= uint64(rule.First) {
+		return true
+	}
+	if rule.Thereafter > 0 && (n-uint64(rule.First))%uint64(rule.Thereafter) == 0 {
+		return true
+	}
+	atomic.AddUint64(&s.dropped, 1)
+	return false
+}
+
+// Dropped returns the number of entries dropped by the sampler.
+func (s *RateSampler) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
@@ -0,0 +1,42 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateSampler(t *testing.T) {
+	s := NewRateSampler(0, map[Level]SamplingRule{
+		ErrorLevel: {First: 3, Thereafter: 10},
+		WarnLevel:  {First: 1},
+	})
+	now := time.Unix(1600000000, 0)
+	s.now = func() time.Time { return now }
+
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetSampler(s.Sample)
+
+	for i := 0; i < 25; i++ {
+		l.Error("connection refused")
+		l.Warn("retrying")
+		l.Info("tick")
+	}
+	l.Error("disk full")
+	out := buf.String()
+	assert.Equal(t, 3+2, strings.Count(out, "connection refused")) // 3 first, then the 13th and 23rd
+	assert.Equal(t, 1, strings.Count(out, "retrying"))
+	assert.Equal(t, 25, strings.Count(out, "tick"))
+	assert.Equal(t, 1, strings.Count(out, "disk full"))
+	assert.Equal(t, uint64(20+24), s.Dropped())
+
+	// the counts restart with the next tick
+	buf.Reset()
+	now = now.Add(time.Second)
+	l.Warn("retrying")
+	l.Warn("retrying")
+	assert.Equal(t, 1, strings.Count(buf.String(), "retrying"))
+}
@@ -0,0 +1,84 @@
+package easylogger
+
+import (
+	"container/list"
+	"io"
+	"sync"
+)
+
+// truncatedSuffix ends the entries cut to fit in the byte budget of a RecentBuffer
+const truncatedSuffix = "...(truncated)\n"
+
+// RecentBuffer keeps the most recent entries in memory, e.g. to dump them when the program
+// crashes. It holds at most MaxEntries entries and MaxBytes bytes, evicting the oldest entries
+// first, and an entry larger than MaxBytes on its own is truncated.
+type RecentBuffer struct {
+	mu         sync.Mutex
+	entries    *list.List // of []byte, oldest at the front
+	bytes      int
+	maxEntries int
+	maxBytes   int
+}
+
+// NewRecentBuffer creates a RecentBuffer, zero or less for maxEntries or maxBytes means no limit.
+func NewRecentBuffer(maxEntries int, maxBytes int) *RecentBuffer {
+	return &RecentBuffer{
+		entries:    list.New(),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+	}
+}
+
+// Write implements io.Writer, p is stored as one entry.
+func (b *RecentBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.maxBytes > 0 && n > b.maxBytes {
+		keep := b.maxBytes - len(truncatedSuffix)
+		if keep < 0 {
+			keep = 0
+		}
+		p = append(p[:keep:keep], truncatedSuffix...)
+		if len(p) > b.maxBytes {
+			p = p[:b.maxBytes]
+		}
+	} else {
+		p = append([]byte(nil), p...)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries.PushBack(p)
+	b.bytes += len(p)
+	for b.entries.Len() > 0 &&
+		((b.maxEntries > 0 && b.entries.Len() > b.maxEntries) || (b.maxBytes > 0 && b.bytes > b.maxBytes)) {
+		b.bytes -= len(b.entries.Remove(b.entries.Front()).([]byte))
+	}
+	return n, nil
+}
+
+// Len returns the number of entries and bytes held.
+func (b *RecentBuffer) Len() (entries int, bytes int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.entries.Len(), b.bytes
+}
+
+// Dump writes the held entries to w, oldest first.
+func (b *RecentBuffer) Dump(w io.Writer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for e := b.entries.Front(); e != nil; e = e.Next() {
+		if _, err := w.Write(e.Value.([]byte)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRecentBuffer makes the logger keep its recent entries in b, nil stops it.
+// It should be called before the logger is used.
+func (this *EasyLogger) SetRecentBuffer(b *RecentBuffer) {
+	this.recent = b
+}
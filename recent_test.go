@@ -0,0 +1,54 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRecentBuffer(t *testing.T) {
+	b := NewRecentBuffer(3, 0)
+	for _, s := range []string{"a\n", "b\n", "c\n", "d\n"} {
+		b.Write([]byte(s))
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, b.Dump(&buf))
+	assert.Equal(t, "b\nc\nd\n", buf.String())
+
+	// the byte budget evicts the oldest entries
+	b = NewRecentBuffer(0, 6)
+	for _, s := range []string{"aa\n", "bb\n", "cc\n"} {
+		b.Write([]byte(s))
+	}
+	entries, size := b.Len()
+	assert.Equal(t, 2, entries)
+	assert.Equal(t, 6, size)
+
+	// a huge entry is truncated instead of blowing the budget
+	b = NewRecentBuffer(10, 32)
+	n, err := b.Write([]byte(strings.Repeat("x", 1000)))
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, n)
+	entries, size = b.Len()
+	assert.Equal(t, 1, entries)
+	assert.Equal(t, 32, size)
+	buf.Reset()
+	b.Dump(&buf)
+	assert.True(t, strings.HasSuffix(buf.String(), truncatedSuffix))
+}
+
+func TestEasyLogger_SetRecentBuffer(t *testing.T) {
+	l := &EasyLogger{out: newSink(&bytes.Buffer{})}
+	b := NewRecentBuffer(2, 0)
+	l.SetRecentBuffer(b)
+	l.Info("one")
+	l.Info("two")
+	l.Info("three")
+
+	var buf bytes.Buffer
+	b.Dump(&buf)
+	assert.NotContains(t, buf.String(), "one")
+	assert.Contains(t, buf.String(), "two")
+	assert.Contains(t, buf.String(), "three")
+}
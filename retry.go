@@ -0,0 +1,89 @@
+package easylogger
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// DefaultRetryPolicy is used by the sinks when no RetryPolicy is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// RetryPolicy describes how a failed operation is retried, it is shared by the
+// network sinks and the archival uploaders so that all of them behave the same.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// Zero or less means one attempt, i.e. no retry.
+	MaxAttempts int
+
+	// InitialBackoff is the wait time before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait time between two attempts.
+	// The default is no cap.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor applied to the backoff after each retry.
+	// Values less than 1 are treated as 1, i.e. a constant backoff.
+	Multiplier float64
+
+	// Jitter randomizes each backoff by up to +/- Jitter*backoff, in [0, 1].
+	Jitter float64
+
+	// Retryable reports whether an error is worth retrying.
+	// The default is to retry every error.
+	Retryable func(err error) bool
+}
+
+// Backoff returns the wait time before the given retry, starting from 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// Do calls op until it succeeds, returns a non-retryable error, the attempts are
+// used up or ctx is done. It returns the last error of op.
+func (p RetryPolicy) Do(ctx context.Context, op func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if attempt >= p.MaxAttempts || (p.Retryable != nil && !p.Retryable(err)) {
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package easylogger
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, time.Duration(0), p.Backoff(0))
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 800*time.Millisecond, p.Backoff(4))
+	assert.Equal(t, time.Second, p.Backoff(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		b := p.Backoff(1)
+		assert.True(t, b >= 50*time.Millisecond && b <= 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	errTemp := errors.New("temporary")
+
+	attempts := 0
+	err := p.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return errTemp
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		return errTemp
+	})
+	assert.Equal(t, errTemp, err)
+	assert.Equal(t, 3, attempts)
+
+	// non-retryable errors are returned at once
+	p.Retryable = func(err error) bool { return err != errTemp }
+	attempts = 0
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		return errTemp
+	})
+	assert.Equal(t, errTemp, err)
+	assert.Equal(t, 1, attempts)
+
+	// a done context stops the retries
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p = RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour}
+	attempts = 0
+	err = p.Do(ctx, func() error {
+		attempts++
+		return errTemp
+	})
+	assert.Equal(t, errTemp, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,96 @@
+package easylogger
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// truncatedMessageSuffix ends the messages cut to the length set by SetMaxMessageLength
+const truncatedMessageSuffix = "...(truncated)"
+
+// terminalEscape matches the CSI and OSC escape sequences, e.g. colors, cursor moves or window titles
+var terminalEscape = regexp.MustCompile("\x1b\\[[0-9:;<=>?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(\x07|\x1b\\\\)?")
+
+// SetMaxMessageLength truncates the messages longer than n bytes in the sanitize stage, zero
+// or less means no limit, it is safe to call at any time.
+func (this *EasyLogger) SetMaxMessageLength(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt32(&this.maxMessage, int32(n))
+}
+
+// WithMaxMessageLength truncates the messages longer than n bytes, see SetMaxMessageLength.
+func WithMaxMessageLength(n int) Option {
+	return func(l *EasyLogger) {
+		l.SetMaxMessageLength(n)
+	}
+}
+
+// sanitizeMessage makes msg safe to write to the files and the consoles: the invalid UTF-8 is
+// replaced by U+FFFD, the terminal escape sequences are removed, the control characters other
+// than \t and \n are replaced by '?' and the messages longer than max bytes are truncated
+func sanitizeMessage(msg string, max int) string {
+	clean := true
+	for i := 0; i < len(msg); i++ {
+		if c := msg[i]; c < ' ' && c != '\t' && c != '\n' || c >= 0x7f {
+			clean = false
+			break
+		}
+	}
+	if !clean {
+		msg = strings.ToValidUTF8(msg, string(utf8.RuneError))
+		if strings.IndexByte(msg, '\x1b') >= 0 {
+			msg = terminalEscape.ReplaceAllString(msg, "")
+		}
+		msg = strings.Map(func(c rune) rune {
+			if c != '\t' && c != '\n' && unicode.IsControl(c) {
+				return '?'
+			}
+			return c
+		}, msg)
+	}
+	if max > 0 && len(msg) > max {
+		cut := max
+		for cut > 0 && !utf8.RuneStart(msg[cut]) {
+			cut--
+		}
+		msg = msg[:cut] + truncatedMessageSuffix
+	}
+	return msg
+}
+
+// sanitizeFields sanitizes the keys and the string values of fields like the messages, without
+// truncating them, the keys having no '\t' or '\n' either. fields is shared with the logger, so
+// it is copied if anything changes.
+func sanitizeFields(fields Fields) Fields {
+	clean, copied := fields, false
+	for k, v := range fields {
+		key := strings.Map(func(c rune) rune {
+			if c == '\t' || c == '\n' {
+				return '?'
+			}
+			return c
+		}, sanitizeMessage(k, 0))
+		s, isString := v.(string)
+		if isString {
+			v = sanitizeMessage(s, 0)
+		}
+		if key == k && (!isString || v == s) {
+			continue
+		}
+		if !copied {
+			clean = make(Fields, len(fields))
+			for k, v := range fields {
+				clean[k] = v
+			}
+			copied = true
+		}
+		delete(clean, k)
+		clean[key] = v
+	}
+	return clean
+}
@@ -0,0 +1,37 @@
+// +build go1.18
+
+package easylogger
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func FuzzSanitizeMessage(f *testing.F) {
+	for _, seed := range []string{"hello", "\x1b[31mred", "\x1b]0;title\x07", "\x00\xff\xfe", "aé\x1b[", "\x1b]8;;http://x\x1b\\link"} {
+		f.Add(seed, 0)
+		f.Add(seed, 3)
+	}
+	f.Fuzz(func(t *testing.T, msg string, max int) {
+		if max < 0 {
+			max = -max
+		}
+		s := sanitizeMessage(msg, max)
+		if !utf8.ValidString(s) {
+			t.Fatalf("invalid UTF-8 %q", s)
+		}
+		if strings.ContainsRune(s, '\x1b') {
+			t.Fatalf("escape left in %q", s)
+		}
+		for _, c := range s {
+			if c != '\t' && c != '\n' && unicode.IsControl(c) {
+				t.Fatalf("control character %U left in %q", c, s)
+			}
+		}
+		if max > 0 && len(s) > max+len(truncatedMessageSuffix) {
+			t.Fatalf("%q longer than %d", s, max)
+		}
+	})
+}
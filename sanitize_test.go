@@ -0,0 +1,75 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeMessage(t *testing.T) {
+	for msg, want := range map[string]string{
+		"plain\ttext\n":                 "plain\ttext\n",
+		"héllo":                         "héllo",
+		"\x1b[31mred\x1b[0m":            "red",
+		"title\x1b]0;pwned\x07!":        "title!",
+		"nul\x00 bell\x07 lone esc\x1b": "nul? bell? lone esc?",
+		"bad \xff\xfe utf8":             "bad � utf8",
+		"\x1b[2J\x1b[1;1Hclear":         "clear",
+		"c1 \u009b31m":                  "c1 ?31m",
+	} {
+		assert.Equal(t, want, sanitizeMessage(msg, 0), msg)
+	}
+
+	assert.Equal(t, "abc"+truncatedMessageSuffix, sanitizeMessage("abcdef", 3))
+	// never cut in the middle of a rune
+	assert.Equal(t, "a"+truncatedMessageSuffix, sanitizeMessage("aéz", 2))
+	assert.Equal(t, "abc", sanitizeMessage("abc", 3))
+}
+
+func TestEasyLogger_SetMaxMessageLength(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetMaxMessageLength(8)
+	l.Info(strings.Repeat("x", 100))
+	assert.Contains(t, buf.String(), "xxxxxxxx"+truncatedMessageSuffix+"\n")
+	assert.NotContains(t, buf.String(), "xxxxxxxxx")
+	assert.True(t, utf8.ValidString(buf.String()))
+
+	c := l.Clone(WithMaxMessageLength(0))
+	buf.Reset()
+	c.Info(strings.Repeat("x", 100))
+	assert.Contains(t, buf.String(), strings.Repeat("x", 100))
+}
+
+func TestSanitizeFields(t *testing.T) {
+	fields := Fields{"k\x1b[2J": "v\x1b]0;x\x07", "n": 1, "line\nkey": "a\x00\nb"}
+	assert.Equal(t, Fields{"k": "v", "n": 1, "line?key": "a?\nb"}, sanitizeFields(fields))
+	// the fields of the logger are left alone
+	assert.Equal(t, "v\x1b]0;x\x07", fields["k\x1b[2J"])
+
+	clean := Fields{"k": "v"}
+	clean["n"] = 1
+	sanitized := sanitizeFields(clean)
+	sanitized["added"] = true
+	assert.Equal(t, true, clean["added"], "copied while clean")
+
+	// the values with control characters are quoted in the text lines
+	assert.Equal(t, `"a\x1bb"`, textValue("a\x1bb"))
+	assert.Equal(t, `"a\x00"`, textValue("a\x00"))
+}
+
+func TestEasyLogger_SanitizeHTTPMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), colorMode: int32(ColorNever)}
+	h := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest("GET", "/a%1b]0;pwned%07b", nil)
+	req.Header.Set(RequestIDHeader, "\x1b[2J")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Contains(t, buf.String(), "GET /ab 200")
+	assert.False(t, strings.ContainsAny(buf.String(), "\x1b\x07"), buf.String())
+}
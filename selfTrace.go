@@ -0,0 +1,144 @@
+package easylogger
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// parts of the fanout stage timed by the self-trace mode
+const (
+	fanoutOutput = iota // the outputs given to the constructor
+	fanoutJSON          // the JSON sidecar
+	fanoutRecent        // the RecentBuffer
+	fanoutRoutes        // the label routes
+	fanoutSinks         // the EntrySinks
+	fanoutParts
+)
+
+var fanoutPartNames = [fanoutParts]string{"output", "json", "recent", "routes", "sinks"}
+
+// SelfTraceConfig configures the self-trace mode, see EnableSelfTrace
+type SelfTraceConfig struct {
+	// Every is the sampling rate, one entry out of Every is traced. The default is 100.
+	Every int
+
+	// Interval is the minimum time between two reports. The default is a minute.
+	Interval time.Duration
+
+	// Output receives the reports. The default is stderr.
+	Output io.Writer
+}
+
+// traceSpans holds the timing of a traced record
+type traceSpans struct {
+	stages [stageCount]time.Duration
+	ran    [stageCount]bool
+	fanout [fanoutParts]time.Duration
+}
+
+// since adds the time elapsed since start to the fanout part, s may be nil
+func (s *traceSpans) since(part int, start time.Time) {
+	if s != nil {
+		s.fanout[part] += time.Since(start)
+	}
+}
+
+type spanStats struct {
+	count int
+	total time.Duration
+	max   time.Duration
+}
+
+func (s *spanStats) add(d time.Duration) {
+	s.count++
+	s.total += d
+	if d > s.max {
+		s.max = d
+	}
+}
+
+func (s *spanStats) String() string {
+	if s.count == 0 {
+		return "not run"
+	}
+	return fmt.Sprintf("avg %v max %v", s.total/time.Duration(s.count), s.max)
+}
+
+// selfTracer aggregates the timing of the traced records and writes the reports
+type selfTracer struct {
+	SelfTraceConfig
+
+	seen uint64 // entries seen, accessed atomically
+
+	mu       sync.Mutex
+	since    time.Time
+	lastSeen uint64
+	traced   int
+	stages   [stageCount]spanStats
+	fanout   [fanoutParts]spanStats
+}
+
+// EnableSelfTrace turns on the self-trace mode: the time spent in each pipeline stage, and in
+// each part of the fanout, is recorded for a sample of the entries and reported periodically,
+// to find out whether slow logging comes from the formatting, the disk or another output.
+// The reports are written after a traced entry once Interval has elapsed, so an idle logger
+// doesn't report. It should be called before the logger is used.
+func (this *EasyLogger) EnableSelfTrace(cfg SelfTraceConfig) {
+	if cfg.Every <= 0 {
+		cfg.Every = 100
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.Output == nil {
+		cfg.Output = stderr
+	}
+	this.tracer = &selfTracer{SelfTraceConfig: cfg, since: time.Now()}
+}
+
+// sample reports whether the next entry is traced
+func (t *selfTracer) sample() bool {
+	return atomic.AddUint64(&t.seen, 1)%uint64(t.Every) == 0
+}
+
+// observe aggregates the spans of a traced record, and writes the report if it is due
+func (t *selfTracer) observe(s *traceSpans) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.traced++
+	for i := range s.stages {
+		if s.ran[i] {
+			t.stages[i].add(s.stages[i])
+		}
+	}
+	if s.ran[len(stages)-1] {
+		for i := range s.fanout {
+			t.fanout[i].add(s.fanout[i])
+		}
+	}
+
+	now := time.Now()
+	if now.Sub(t.since) < t.Interval {
+		return
+	}
+	seen := atomic.LoadUint64(&t.seen)
+	var b strings.Builder
+	fmt.Fprintf(&b, "EasyLogger self-trace: %d of %d entries traced in %v\n",
+		t.traced, seen-t.lastSeen, now.Sub(t.since).Round(time.Millisecond))
+	for i := range t.stages {
+		fmt.Fprintf(&b, "  %-14s %s\n", stages[i].name, &t.stages[i])
+	}
+	for i := range t.fanout {
+		fmt.Fprintf(&b, "  %-14s %s\n", StageFanout+"/"+fanoutPartNames[i], &t.fanout[i])
+	}
+	io.WriteString(t.Output, b.String())
+
+	t.since, t.lastSeen, t.traced = now, seen, 0
+	t.stages = [stageCount]spanStats{}
+	t.fanout = [fanoutParts]spanStats{}
+}
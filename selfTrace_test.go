@@ -0,0 +1,37 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_EnableSelfTrace(t *testing.T) {
+	var buf, report bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.EnableSelfTrace(SelfTraceConfig{Every: 2, Interval: time.Nanosecond, Output: &report})
+
+	for i := 0; i < 4; i++ {
+		l.Info("entry", i)
+	}
+	assert.Equal(t, 4, strings.Count(buf.String(), "entry"))
+
+	s := report.String()
+	assert.Equal(t, 2, strings.Count(s, "EasyLogger self-trace: 1 of 2 entries traced"))
+	for _, name := range []string{"sanitize", "format", "fanout/output", "fanout/routes"} {
+		assert.Contains(t, s, "  "+name+" ")
+	}
+}
+
+func TestEasyLogger_SelfTraceDroppedEntries(t *testing.T) {
+	var report bytes.Buffer
+	l := &EasyLogger{out: newSink(&bytes.Buffer{})}
+	l.SetSampler(func(e Entry) bool { return false })
+	l.EnableSelfTrace(SelfTraceConfig{Every: 1, Interval: time.Nanosecond, Output: &report})
+
+	l.Info("dropped")
+	assert.Contains(t, report.String(), "  format         not run")
+	assert.NotContains(t, report.String(), "  sample         not run")
+}
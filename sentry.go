@@ -0,0 +1,294 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sentryPackage prefixes the functions of EasyLogger, left out of the stack traces
+const sentryPackage = "github.com/joeqian10/EasyLogger/v2."
+
+// sentryLevels are the Sentry levels of the levels
+var sentryLevels = [...]string{"debug", "debug", "info", "warning", "error", "fatal", "fatal"}
+
+// SentryConfig configures a SentrySink
+type SentryConfig struct {
+	// DSN is the Data Source Name of the Sentry project, e.g.
+	// "https://public@o0.ingest.sentry.io/42".
+	DSN string
+
+	// Levels are the levels of the entries sent, the default is ERROR, FATAL and PANIC.
+	Levels []Level
+
+	// SampleRate is the fraction of the entries sent, in (0, 1], the default is 1.
+	SampleRate float64
+
+	// MaxEventsPerMinute limits the number of entries sent, the entries above it are dropped,
+	// the default is no limit. Sentry can slow down the sink as well when it is rate limited.
+	MaxEventsPerMinute int
+
+	// Environment, Release and ServerName describe the events, the default ServerName is
+	// os.Hostname.
+	Environment string
+	Release     string
+	ServerName  string
+
+	// BufferSize is the maximum number of entries waiting to be sent, the default is 100.
+	BufferSize int
+
+	// Client sends the events, the default is an http.Client with a timeout of 10 seconds.
+	Client *http.Client
+}
+
+// SentrySink is an EntrySink reporting the errors to Sentry, in the background, see NewSentrySink
+type SentrySink struct {
+	config   SentryConfig
+	storeURL string
+	header   http.Header
+	levels   [PanicLevel + 1]bool
+	ch       chan []byte
+	done     chan struct{}
+	dropped  uint64 // accessed atomically
+	gate     sinkGate
+
+	mu          sync.Mutex
+	windowStart time.Time
+	windowCount int
+	pausedUntil time.Time // only used by the sending goroutine
+}
+
+// NewSentrySink returns a SentrySink sending the ERROR, FATAL and PANIC entries to the Sentry
+// project of the DSN as events, with their fields as extra data and the stack trace of the
+// logging call. The stack traces are only available when the logger is not in asynchronous
+// mode. The entries are sent in the background and dropped when the buffer is full.
+//
+//	s, err := easylogger.NewSentrySink(easylogger.SentryConfig{DSN: dsn, SampleRate: 0.5, MaxEventsPerMinute: 60})
+//	if err == nil {
+//		l.AddSink(s)
+//		defer s.Close()
+//	}
+func NewSentrySink(config SentryConfig) (*SentrySink, error) {
+	u, err := url.Parse(config.DSN)
+	if err != nil {
+		return nil, coded(CodeConfig, err)
+	}
+	project := strings.Trim(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || project == "" {
+		return nil, coded(CodeConfig, errors.New("invalid Sentry DSN, expected https://<key>@<host>/<project>"))
+	}
+	if len(config.Levels) == 0 {
+		config.Levels = []Level{ErrorLevel, FatalLevel, PanicLevel}
+	}
+	if config.SampleRate <= 0 || config.SampleRate > 1 {
+		config.SampleRate = 1
+	}
+	if config.ServerName == "" {
+		config.ServerName, _ = os.Hostname()
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	s := &SentrySink{
+		config:   config,
+		storeURL: fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, project),
+		header: http.Header{"X-Sentry-Auth": {"Sentry sentry_version=7, sentry_client=easylogger/1.0, sentry_key=" +
+			u.User.Username()}},
+		ch:   make(chan []byte, config.BufferSize),
+		done: make(chan struct{}),
+	}
+	for _, level := range config.Levels {
+		if level >= TraceLevel && level <= PanicLevel {
+			s.levels[level] = true
+		}
+	}
+	go s.run()
+	return s, nil
+}
+
+// WriteEntry implements EntrySink.
+func (s *SentrySink) WriteEntry(e Entry) error {
+	if e.Level < TraceLevel || e.Level > PanicLevel || !s.levels[e.Level] {
+		return nil
+	}
+	if s.config.SampleRate < 1 && rand.Float64() >= s.config.SampleRate {
+		return nil
+	}
+	if !s.allow(time.Now()) {
+		return nil
+	}
+	event, err := s.event(e, sentryStack())
+	if err != nil {
+		return err
+	}
+	err = s.gate.send(func() bool {
+		select {
+		case s.ch <- event:
+			return true
+		default:
+			return false
+		}
+	})
+	if err != nil {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return err
+}
+
+// allow reports whether an event can be sent at now according to MaxEventsPerMinute
+func (s *SentrySink) allow(now time.Time) bool {
+	if s.config.MaxEventsPerMinute <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.windowStart) >= time.Minute {
+		s.windowStart, s.windowCount = now, 0
+	}
+	if s.windowCount >= s.config.MaxEventsPerMinute {
+		return false
+	}
+	s.windowCount++
+	return true
+}
+
+// Dropped returns the number of entries dropped because the buffer was full, Sentry was rate
+// limiting the sink, or they could not be sent. The entries left out by the sampling and
+// MaxEventsPerMinute are not counted.
+func (s *SentrySink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close sends the buffered entries, then stops the sink.
+func (s *SentrySink) Close() error {
+	s.gate.close(func() { close(s.ch) })
+	<-s.done
+	return nil
+}
+
+// run sends the events, pausing while Sentry is rate limiting the sink
+func (s *SentrySink) run() {
+	defer close(s.done)
+	for event := range s.ch {
+		if time.Now().Before(s.pausedUntil) {
+			atomic.AddUint64(&s.dropped, 1)
+			continue
+		}
+		err := postJSON(s.config.Client, s.storeURL, s.header, event)
+		if err == nil {
+			continue
+		}
+		atomic.AddUint64(&s.dropped, 1)
+		if se, ok := err.(*statusError); ok && se.status == http.StatusTooManyRequests {
+			pause := se.retryAfter
+			if pause <= 0 {
+				pause = time.Minute
+			}
+			s.pausedUntil = time.Now().Add(pause)
+		}
+		diagf(CodeSinkWrite, "entry not sent to Sentry: %v", err)
+	}
+}
+
+// sentryFrame is a frame of a stack trace in a Sentry event
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// sentryStack returns the stack trace of the logging call, oldest frame first, without the
+// frames of EasyLogger and of the runtime
+func sentryStack() []sentryFrame {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	var stack []sentryFrame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		library := strings.HasPrefix(f.Function, sentryPackage) && !strings.HasSuffix(f.File, "_test.go")
+		if !library && f.Function != "" && !strings.HasPrefix(f.Function, "runtime.") {
+			module, function := f.Function, f.Function
+			if i := strings.LastIndexByte(f.Function, '/'); i >= 0 {
+				if j := strings.IndexByte(f.Function[i:], '.'); j >= 0 {
+					module, function = f.Function[:i+j], f.Function[i+j+1:]
+				}
+			} else if j := strings.IndexByte(f.Function, '.'); j >= 0 {
+				module, function = f.Function[:j], f.Function[j+1:]
+			}
+			stack = append(stack, sentryFrame{Function: function, Module: module, Filename: shortFile(f.File),
+				AbsPath: f.File, Lineno: f.Line, InApp: !strings.Contains(f.File, "/go/src/") &&
+					!strings.Contains(f.File, "/pkg/mod/")})
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
+
+// shortFile returns the last directory and the name of the file path
+func shortFile(path string) string {
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		if j := strings.LastIndexByte(path[:i], '/'); j >= 0 {
+			return path[j+1:]
+		}
+	}
+	return path
+}
+
+// event renders e as the JSON of a Sentry event with the stack trace
+func (s *SentrySink) event(e Entry, stack []sentryFrame) ([]byte, error) {
+	extra := make(map[string]interface{}, len(e.Fields))
+	for k, v := range e.Fields {
+		extra[k] = jsonValue(v)
+	}
+	level := sentryLevels[e.Level]
+	exception := map[string]interface{}{"type": e.Level.String(), "value": e.Message}
+	if len(stack) > 0 {
+		exception["stacktrace"] = map[string]interface{}{"frames": stack}
+	}
+	event := map[string]interface{}{
+		"event_id":    strings.Replace(UUIDv7Generator{}.NewID(), "-", "", -1),
+		"timestamp":   e.Time.UTC().Format(time.RFC3339Nano),
+		"level":       level,
+		"logger":      "EasyLogger",
+		"platform":    "go",
+		"server_name": s.config.ServerName,
+		"message":     map[string]string{"formatted": e.Message},
+		"exception":   map[string]interface{}{"values": []interface{}{exception}},
+		"tags":        map[string]string{"gid": fmt.Sprint(e.GID)},
+	}
+	if len(extra) > 0 {
+		event["extra"] = extra
+	}
+	if e.Caller != "" {
+		event["culprit"] = e.Caller
+	}
+	if s.config.Environment != "" {
+		event["environment"] = s.config.Environment
+	}
+	if s.config.Release != "" {
+		event["release"] = s.config.Release
+	}
+	return json.Marshal(event)
+}
@@ -0,0 +1,79 @@
+package easylogger
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSentrySink(t *testing.T) {
+	events := make(chan map[string]interface{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/42/store/", r.URL.Path)
+		assert.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=public")
+		b, _ := ioutil.ReadAll(r.Body)
+		var event map[string]interface{}
+		assert.Nil(t, json.Unmarshal(b, &event))
+		events <- event
+	}))
+	defer srv.Close()
+
+	_, err := NewSentrySink(SentryConfig{DSN: "http://127.0.0.1/42"})
+	assert.Equal(t, CodeConfig, ErrorCodeOf(err))
+
+	dsn := strings.Replace(srv.URL, "http://", "http://public@", 1) + "/42"
+	s, err := NewSentrySink(SentryConfig{DSN: dsn, Environment: "prod", MaxEventsPerMinute: 2})
+	assert.Nil(t, err)
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	l.AddSink(s)
+	l.Warn("ignored")
+	l.WithFields(Fields{"user": 42}).Error("boom")
+	l.Error("second")
+	l.Error("rate limited")
+	assert.Nil(t, s.Close())
+	close(events)
+
+	var all []map[string]interface{}
+	for event := range events {
+		all = append(all, event)
+	}
+	assert.Len(t, all, 2)
+	event := all[0]
+	assert.Equal(t, "error", event["level"])
+	assert.Equal(t, "prod", event["environment"])
+	assert.Equal(t, map[string]interface{}{"formatted": "boom"}, event["message"])
+	assert.Equal(t, map[string]interface{}{"user": float64(42)}, event["extra"])
+	assert.Len(t, event["event_id"], 32)
+
+	exception := event["exception"].(map[string]interface{})["values"].([]interface{})[0].(map[string]interface{})
+	frames := exception["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+	last := frames[len(frames)-1].(map[string]interface{})
+	assert.Equal(t, "TestSentrySink", last["function"])
+	assert.True(t, strings.HasSuffix(last["filename"].(string), "sentry_test.go"))
+	assert.Equal(t, "second", all[1]["message"].(map[string]interface{})["formatted"])
+}
+
+func TestSentrySink_RateLimitedBySentry(t *testing.T) {
+	requests := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	s, err := NewSentrySink(SentryConfig{DSN: strings.Replace(srv.URL, "http://", "http://k@", 1) + "/1"})
+	assert.Nil(t, err)
+	e := Entry{Time: time.Now(), Level: FatalLevel, Message: "down"}
+	assert.Nil(t, s.WriteEntry(e))
+	assert.Nil(t, s.WriteEntry(e))
+	assert.Nil(t, s.Close())
+	// the second one is dropped without being sent
+	assert.Len(t, requests, 1)
+	assert.Equal(t, uint64(2), s.Dropped())
+}
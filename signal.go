@@ -0,0 +1,32 @@
+package easylogger
+
+import (
+	"os"
+	"os/signal"
+)
+
+// RotateOnSignal rotates the log files when the process receives one of sigs, typically
+// syscall.SIGHUP sent by logrotate in its postrotate script. A failed rotation is logged
+// as an ERROR entry. The returned function stops the handling of the signals.
+func (this *EasyLogger) RotateOnSignal(sigs ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				if err := this.Rotate(); err != nil {
+					diagf(CodeRotation, "rotation on %v failed: %v", sig, err)
+					this.Errorf("rotation on %v failed: %v", sig, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
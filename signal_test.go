@@ -0,0 +1,33 @@
+// +build !windows,!plan9
+
+package easylogger
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestEasyLogger_RotateOnSignal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewTimeRotatingEasyLogger(dir, 1, 0, true, false, 0, "", false)
+	stop := l.RotateOnSignal(syscall.SIGHUP)
+	defer stop()
+	l.Info("before")
+
+	assert.Nil(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	rotated := filepath.Join(dir, time.Now().Format(FileNameTimeFormat)+".1"+FileNameExt)
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if _, err = os.Stat(rotated); err == nil {
+			break
+		}
+	}
+	assert.Nil(t, err)
+}
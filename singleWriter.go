@@ -0,0 +1,46 @@
+package easylogger
+
+import (
+	"sync/atomic"
+)
+
+// SetSingleWriter turns the single writer mode on or off. In single writer mode the caller
+// guarantees that the logger and its clones are used by one goroutine at a time, e.g. a game
+// loop or a batch job, so the entries are written without locking the outputs and without
+// capturing the goroutine id, which is always 0. The outputs must not be modified with
+// AddWriter or RemoveWriter while the logger is in use, and the Loggers writing the files keep
+// their own lock, which is uncontended.
+//
+// Breaking the guarantee corrupts the lines silently, so in strict mode the logger detects the
+// concurrent writes and panics, see SetStrict. Enable the strict mode in the tests.
+func (this *EasyLogger) SetSingleWriter(single bool) {
+	var v int32
+	if single {
+		v = 1
+	}
+	atomic.StoreInt32(&this.singleWriter, v)
+}
+
+// WithSingleWriter turns the single writer mode on or off, see SetSingleWriter.
+func WithSingleWriter(single bool) Option {
+	return func(l *EasyLogger) {
+		l.SetSingleWriter(single)
+	}
+}
+
+// isSingleWriter reports whether the logger is in single writer mode
+func (this *EasyLogger) isSingleWriter() bool {
+	return atomic.LoadInt32(&this.singleWriter) == 1
+}
+
+// enterSingleWriter marks the outputs in use in single writer mode when the strict mode is on,
+// panicking if another goroutine is writing to them. The returned function releases them.
+func (this *EasyLogger) enterSingleWriter() func() {
+	if atomic.LoadInt32(&this.strict) == 0 {
+		return func() {}
+	}
+	if !atomic.CompareAndSwapInt32(&this.out.writing, 0, 1) {
+		this.misuse("concurrent writes in single writer mode")
+	}
+	return func() { atomic.StoreInt32(&this.out.writing, 0) }
+}
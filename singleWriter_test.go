@@ -0,0 +1,31 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEasyLogger_SetSingleWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetSingleWriter(true)
+	l.Info("hello")
+	assert.Contains(t, buf.String(), " GID 0, hello\n")
+
+	c := l.Clone()
+	assert.True(t, c.isSingleWriter())
+	c = l.Clone(WithSingleWriter(false))
+	c.Info("again")
+	assert.NotContains(t, buf.String(), " GID 0, again\n")
+
+	// a write in progress on another goroutine is detected in strict mode only
+	atomic.StoreInt32(&l.out.writing, 1)
+	assert.NotPanics(t, func() { l.Info("tolerated") })
+	l.SetStrict(true)
+	assert.Panics(t, func() { l.Info("concurrent") })
+	atomic.StoreInt32(&l.out.writing, 0)
+	assert.NotPanics(t, func() { l.Info("alone") })
+	assert.Equal(t, int32(0), atomic.LoadInt32(&l.out.writing))
+}
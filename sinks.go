@@ -0,0 +1,61 @@
+package easylogger
+
+import (
+	"sync"
+)
+
+// EntrySink receives the entries rather than their rendered lines, e.g. to forward them with
+// their level and fields to syslog or a log collector, see AddSink
+type EntrySink interface {
+	WriteEntry(e Entry) error
+}
+
+// entrySinks holds the EntrySinks of a logger and its clones
+type entrySinks struct {
+	mu    sync.RWMutex
+	sinks []EntrySink
+}
+
+// AddSink adds s to the outputs of the logger, it receives every entry written to the log files.
+// The logger and its clones share their outputs, so s is added to all of them.
+func (this *EasyLogger) AddSink(s EntrySink) {
+	if this.sinks == nil {
+		this.sinks = &entrySinks{}
+	}
+	this.sinks.mu.Lock()
+	defer this.sinks.mu.Unlock()
+	this.sinks.sinks = append(this.sinks.sinks, s)
+}
+
+// RemoveSink removes s from the outputs of the logger, it returns false if s is not an output.
+func (this *EasyLogger) RemoveSink(s EntrySink) bool {
+	if this.sinks == nil {
+		return false
+	}
+	this.sinks.mu.Lock()
+	defer this.sinks.mu.Unlock()
+	for i, x := range this.sinks.sinks {
+		if ls, ok := x.(levelSink); ok {
+			x = ls.EntrySink
+		}
+		if x == s {
+			this.sinks.sinks = append(this.sinks.sinks[:i:i], this.sinks.sinks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// write writes e to the sinks, a failing sink doesn't prevent the others from getting e
+func (s *entrySinks) write(e Entry) {
+	if s == nil {
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sink := range s.sinks {
+		if err := sink.WriteEntry(e); err != nil {
+			diagf(codeOf(err, CodeSinkWrite), "%s entry not written to %T: %v", e.Level, sink, err)
+		}
+	}
+}
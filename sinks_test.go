@@ -0,0 +1,112 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+)
+
+// entriesSink records the entries it receives
+type entriesSink struct {
+	mu      sync.Mutex
+	entries []Entry
+	err     error
+}
+
+func (s *entriesSink) WriteEntry(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return s.err
+}
+
+func TestEasyLogger_AddSink(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	failing := &entriesSink{err: errors.New("down")}
+	s := &entriesSink{}
+	l.AddSink(failing)
+	l.AddSink(s)
+
+	c := l.With("user", 42)
+	c.Warn("hello")
+	assert.Equal(t, 1, len(s.entries))
+	assert.Equal(t, 1, len(failing.entries))
+	assert.Equal(t, WarnLevel, s.entries[0].Level)
+	assert.Equal(t, "hello", s.entries[0].Message)
+	assert.Equal(t, Fields{"user": 42}, s.entries[0].Fields)
+	assert.Contains(t, buf.String(), "hello")
+
+	assert.True(t, c.RemoveSink(s))
+	assert.False(t, c.RemoveSink(s))
+	l.Info("gone")
+	assert.Equal(t, 1, len(s.entries))
+	assert.Equal(t, 2, len(failing.entries))
+}
+
+func TestEasyLogger_AddSinkSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	w := &gateWriter{gate: make(chan struct{})}
+	l := &EasyLogger{out: newSink(w)}
+	s := &entriesSink{}
+	l.AddSink(s)
+	l.EnableAsync(AsyncConfig{QueueSize: 1, Overflow: OverflowSpill, SpillDir: dir})
+
+	// the spilled entries keep their fields
+	for i := 0; i < 5; i++ {
+		l.With("i", i, "err", errors.New("boom")).Info("spilled")
+	}
+	assert.True(t, l.SpilledEntries() > 0)
+	close(w.gate)
+	l.Flush()
+	assert.Equal(t, 5, len(s.entries))
+	last := s.entries[4].Fields
+	assert.Equal(t, "boom", last["err"])
+	assert.EqualValues(t, 4, last["i"])
+}
+
+// closingSink is an entriesSink counting its Close calls
+type closingSink struct {
+	entriesSink
+	closed int
+}
+
+func (s *closingSink) Close() error {
+	s.closed++
+	return nil
+}
+
+// closingWriter is a bytes.Buffer counting its Close calls
+type closingWriter struct {
+	bytes.Buffer
+	closed int
+}
+
+func (w *closingWriter) Close() error {
+	w.closed++
+	return nil
+}
+
+func TestEasyLogger_ShutdownClosesOutputs(t *testing.T) {
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	s, w, r := &closingSink{}, &closingWriter{}, &closingWriter{}
+	l.AddSinkLevel(s, WarnLevel)
+	l.AddWriter(w)
+	l.AddWriter(os.Stdout)
+	l.Route("audit", r)
+	l.Route("audit2", r)
+
+	assert.Nil(t, l.Close())
+	assert.Equal(t, 1, s.closed)
+	assert.Equal(t, 1, w.closed)
+	assert.Equal(t, 1, r.closed)
+	_, err := os.Stdout.Stat()
+	assert.Nil(t, err)
+}
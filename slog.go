@@ -0,0 +1,98 @@
+// +build go1.21
+
+package easylogger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler writing the records with an EasyLogger, see NewSlogHandler
+type SlogHandler struct {
+	el    *EasyLogger
+	group string // prefix of the keys of the attributes, e.g. "request."
+}
+
+// ensure we always implement slog.Handler
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler returns a slog.Handler writing the records with el, so that the programs using
+// log/slog get the rotation, the colors and the GID of EasyLogger:
+//
+//	slog.SetDefault(slog.New(easylogger.NewSlogHandler(el)))
+//
+// The attributes become fields, the keys of the attributes in a group are prefixed by the name
+// of the group and a dot. The slog levels map to the closest Level, at most ERROR.
+func NewSlogHandler(el *EasyLogger) *SlogHandler {
+	return &SlogHandler{el: el}
+}
+
+// slogLevel returns the Level of the slog level l
+func slogLevel(l slog.Level) Level {
+	switch {
+	case l < slog.LevelDebug:
+		return TraceLevel
+	case l < slog.LevelInfo:
+		return DebugLevel
+	case l < slog.LevelWarn:
+		return InfoLevel
+	case l < slog.LevelError:
+		return WarnLevel
+	}
+	return ErrorLevel
+}
+
+// Enabled implements slog.Handler.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.el.Enabled(slogLevel(level))
+}
+
+// Handle implements slog.Handler.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	el := h.el.ctxLogger(ctx)
+	if r.NumAttrs() > 0 {
+		fields := make(Fields, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			addAttr(fields, h.group, a)
+			return true
+		})
+		el = el.WithFields(fields)
+	}
+	return el.emitAt(slogLevel(r.Level), r.Message, r.PC)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(Fields, len(attrs))
+	for _, a := range attrs {
+		addAttr(fields, h.group, a)
+	}
+	return &SlogHandler{el: h.el.WithFields(fields), group: h.group}
+}
+
+// WithGroup implements slog.Handler.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{el: h.el, group: h.group + name + "."}
+}
+
+// addAttr adds the attribute a to fields, with its key prefixed by group
+func addAttr(fields Fields, group string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		prefix := group
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addAttr(fields, prefix, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	fields[group+a.Key] = v.Any()
+}
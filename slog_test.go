@@ -0,0 +1,41 @@
+// +build go1.21
+
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetFormat(JSONFormat)
+	l.SetLevel(InfoLevel)
+
+	logger := slog.New(NewSlogHandler(l)).With("service", "billing")
+	logger.Debug("dropped")
+	logger.WithGroup("req").Info("handled", "id", 7, slog.Group("user", "name", "ann"))
+	logger.Log(context.Background(), slog.LevelError+4, "worse than error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &m))
+	assert.Equal(t, "INFO", m["level"])
+	assert.Equal(t, "handled", m["msg"])
+	assert.Equal(t, "billing", m["service"])
+	assert.Equal(t, float64(7), m["req.id"])
+	assert.Equal(t, "ann", m["req.user.name"])
+	assert.Contains(t, m["caller"], "slog_test.go")
+	assert.NotNil(t, m["gid"])
+
+	m = nil
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &m))
+	assert.Equal(t, "ERROR", m["level"])
+}
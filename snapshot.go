@@ -0,0 +1,62 @@
+package easylogger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrSnapshotUnsupported is returned by SnapshotCurrent when the text output is not a Logger
+var ErrSnapshotUnsupported = errors.New("the log file does not support snapshots")
+
+// SnapshotCurrent copies the current log file to dst as it is at the time of the call, e.g. for
+// a support bundle. The Logger is only locked to open the file, the entries written during the
+// copy are not included, and the copy goes on if the file is rotated meanwhile.
+func (l *Logger) SnapshotCurrent(dst io.Writer) error {
+	l.mu.Lock()
+	if l.currentFile == nil {
+		if err := l.openExistingOrNew(); err != nil {
+			l.mu.Unlock()
+			return err
+		}
+	}
+	fi, err := l.currentFile.Stat()
+	if err != nil {
+		l.mu.Unlock()
+		return err
+	}
+	f, err := os.Open(l.currentFile.Name())
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.CopyN(dst, f, fi.Size())
+	return err
+}
+
+// SnapshotCurrent writes the entries queued in asynchronous mode, then copies the current text
+// log file to dst, see Logger.SnapshotCurrent. It returns ErrSnapshotUnsupported for the size
+// rotating loggers.
+func (this *EasyLogger) SnapshotCurrent(dst io.Writer) error {
+	if len(this.files) == 0 {
+		return ErrSnapshotUnsupported
+	}
+	tl, ok := this.files[0].(*Logger)
+	if !ok {
+		return ErrSnapshotUnsupported
+	}
+	this.Flush()
+	return tl.SnapshotCurrent(dst)
+}
+
+// SnapshotCurrentGzip is like SnapshotCurrent with the copy compressed with gzip.
+func (this *EasyLogger) SnapshotCurrentGzip(dst io.Writer) error {
+	gz := gzip.NewWriter(dst)
+	if err := this.SnapshotCurrent(gz); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,59 @@
+package easylogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEasyLogger_SnapshotCurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir))
+	for i := 0; i < 100; i++ {
+		l.Info("before")
+	}
+
+	// the snapshot holds whole entries while the logger is in use
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				l.Info("during")
+			}
+		}
+	}()
+	var buf bytes.Buffer
+	assert.Nil(t, l.SnapshotCurrent(&buf))
+	close(stop)
+	wg.Wait()
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+	assert.Equal(t, 100, strings.Count(buf.String(), "before"))
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		_, err := ParseTextEntry(line)
+		assert.Nil(t, err)
+	}
+
+	var gzBuf bytes.Buffer
+	assert.Nil(t, l.SnapshotCurrentGzip(&gzBuf))
+	gz, err := gzip.NewReader(&gzBuf)
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(gz)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(b), buf.String()))
+
+	assert.Equal(t, ErrSnapshotUnsupported, NewEasyLogger().SnapshotCurrent(&buf))
+}
@@ -0,0 +1,183 @@
+package easylogger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// spillBatch is the maximum number of entries read back from the spill file at once
+const spillBatch = 100
+
+// spill is the overflow of an asynchronous queue: once the queue is full, the entries are
+// appended to a temporary file as JSON lines, until it has been read back entirely, so that
+// their order is kept.
+type spill struct {
+	count uint64 // entries spilled, accessed atomically
+
+	mu      sync.Mutex
+	f       *os.File
+	written int64         // end of the entries written
+	read    int64         // end of the entries read back
+	loggers []*EasyLogger // the loggers of the spilled entries, which can't be serialized
+	ids     map[*EasyLogger]int
+}
+
+// spilledRecord is the part of a record needed to write it, see EasyLogger.write
+type spilledRecord struct {
+	Logger     int       `json:"l"`
+	Time       time.Time `json:"t"`
+	Level      Level     `json:"v"`
+	GID        uint64    `json:"g"`
+	Message    string    `json:"m"`
+	Labels     []string  `json:"b,omitempty"`
+	JSONFormat bool      `json:"jf,omitempty"`
+	Plain      bool      `json:"p,omitempty"`
+	Text       string    `json:"x,omitempty"`
+	ColorText  string    `json:"c,omitempty"`
+	JSON       []byte    `json:"j,omitempty"`
+	File       string    `json:"f,omitempty"`
+	FileLine   int       `json:"fl,omitempty"`
+	Caller     string    `json:"cl,omitempty"`
+	Function   string    `json:"fn,omitempty"`
+	Stack      string    `json:"st,omitempty"`
+	Fields     Fields    `json:"fi,omitempty"`
+}
+
+// spillFields returns fields with the values which can't be spilled as JSON replaced by their text,
+// the values of the spilled entries are read back as their JSON equivalent, e.g. float64 for int
+func spillFields(fields Fields) Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+	spilled := make(Fields, len(fields))
+	for k, v := range fields {
+		v = jsonValue(v)
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprint(v)
+		}
+		spilled[k] = v
+	}
+	return spilled
+}
+
+// discard removes the spill file with the entries not read back yet
+func (s *spill) discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return
+	}
+	s.f.Close()
+	os.Remove(s.f.Name())
+	s.f, s.loggers, s.ids = nil, nil, nil
+}
+
+// empty reports whether all the spilled entries have been read back
+func (s *spill) empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f == nil
+}
+
+// push queues it in q if it is not spilling, or spills it. It reports whether it was
+// queued or spilled, the error explains why not.
+func (s *spill) push(q *asyncQueue, it asyncItem) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		select {
+		case q.ch <- it:
+			return true, nil
+		default:
+		}
+		f, err := ioutil.TempFile(q.SpillDir, "easylogger-spill-")
+		if err != nil {
+			return false, err
+		}
+		s.f, s.written, s.read = f, 0, 0
+		s.loggers, s.ids = nil, make(map[*EasyLogger]int)
+	}
+
+	id, ok := s.ids[it.l]
+	if !ok {
+		id = len(s.loggers)
+		s.loggers = append(s.loggers, it.l)
+		s.ids[it.l] = id
+	}
+	r := it.r
+	b, err := json.Marshal(spilledRecord{id, r.Time, r.Level, r.GID, r.Message, r.Labels,
+		r.jsonFormat, r.plain, r.text, r.colorText, r.json, r.file, r.fileLine, r.Caller, r.Function, r.Stack, spillFields(r.Fields)})
+	if err != nil {
+		return false, err
+	}
+	b = append(b, '\n')
+	if s.written+int64(len(b)) > q.MaxSpillSize {
+		return false, nil
+	}
+	n, err := s.f.Write(b)
+	s.written += int64(n)
+	if err != nil {
+		return false, err
+	}
+	atomic.AddUint64(&s.count, 1)
+	return true, nil
+}
+
+// drain writes a batch of spilled entries, it reports whether there was any
+func (s *spill) drain() bool {
+	s.mu.Lock()
+	f, from, to, loggers := s.f, s.read, s.written, s.loggers
+	s.mu.Unlock()
+	if f == nil {
+		return false
+	}
+
+	// only the entries written so far are read, the file is appended to meanwhile
+	br := bufio.NewReader(io.NewSectionReader(f, from, to-from))
+	read := from
+	for i := 0; i < spillBatch && read < to; i++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil {
+			// a truncated entry, give up on the rest
+			diagf(CodeSpill, "spill file %s unreadable: %v", f.Name(), err)
+			read = to
+			break
+		}
+		read += int64(len(line))
+		var sr spilledRecord
+		if err := json.Unmarshal(line, &sr); err != nil || sr.Logger >= len(loggers) {
+			continue
+		}
+		r := &record{
+			Entry: Entry{Time: sr.Time, Level: sr.Level, GID: sr.GID, Caller: sr.Caller, Function: sr.Function,
+				Message: sr.Message, Stack: sr.Stack, Fields: sr.Fields, Labels: sr.Labels},
+			jsonFormat: sr.JSONFormat,
+			plain:      sr.Plain,
+			text:       sr.Text,
+			colorText:  sr.ColorText,
+			json:       sr.JSON,
+			file:       sr.File,
+			fileLine:   sr.FileLine,
+		}
+		loggers[sr.Logger].write(r)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.read = read
+	if s.read == s.written {
+		// all read back, the next entries go to the queue again
+		s.f.Close()
+		os.Remove(s.f.Name())
+		s.f, s.loggers, s.ids = nil, nil, nil
+	}
+	return true
+}
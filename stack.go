@@ -0,0 +1,90 @@
+package easylogger
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of frames of the stack traces
+const maxStackDepth = 64
+
+// EnableStackTrace adds the stack trace of the logging call to the ERROR, FATAL and PANIC
+// entries, rendered below the line in text outputs and as the "stack" key in JSON outputs:
+//
+//	[ERROR] GID 1, payment failed
+//		main.(*Server).charge()
+//			/src/server.go:42
+//		main.(*Server).handle()
+//			/src/server.go:27
+//
+// It should be called before the logger is used.
+func (this *EasyLogger) EnableStackTrace(enabled bool) {
+	this.stackOn = enabled
+}
+
+// WithStackTrace adds the stack trace to the ERROR, FATAL and PANIC entries, see EnableStackTrace.
+func WithStackTrace(enabled bool) Option {
+	return func(l *EasyLogger) {
+		l.EnableStackTrace(enabled)
+	}
+}
+
+// ErrorWithStack logs err at the ERROR level with the stack trace of the call, whether
+// EnableStackTrace is on or not.
+func (this *EasyLogger) ErrorWithStack(err error) {
+	if !this.Enabled(ErrorLevel) {
+		return
+	}
+	this.emitStack(ErrorLevel, fmt.Sprint(err), callers(2+this.callerSkip))
+}
+
+// wantsStack reports whether the entries of level get a stack trace
+func (this *EasyLogger) wantsStack(level Level) bool {
+	return this.stackOn && level >= ErrorLevel
+}
+
+// emitStack logs an entry with the stack trace pcs, as returned by callers
+func (this *EasyLogger) emitStack(level Level, msg string, pcs []uintptr) error {
+	this.checkClosed()
+	var pc uintptr
+	if len(pcs) > 0 && this.needsCaller() {
+		pc = pcs[0]
+	}
+	r := this.newRecord(level, msg, pc)
+	if r.Stack == "" {
+		// the stack trace of the error of WithError takes precedence
+		r.Stack = formatStack(pcs)
+	}
+	return this.process(r)
+}
+
+// callers returns the program counters of the stack after skip frames, 0 identifying the frame
+// of callers itself like runtime.Callers
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(skip+1, pcs)]
+}
+
+// formatStack renders the frames of pcs like the stack traces of the panics, without the
+// frames of the runtime
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if f.Function != "" && !strings.HasPrefix(f.Function, "runtime.") {
+			b.WriteString(f.Function + "()\n\t" + f.File + ":" + strconv.Itoa(f.Line) + "\n")
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// indentStack indents the lines of a stack trace below a text line
+func indentStack(stack string) string {
+	return "\t" + strings.Replace(strings.TrimSuffix(stack, "\n"), "\n", "\n\t", -1) + "\n"
+}
@@ -0,0 +1,50 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEasyLogger_EnableStackTrace(t *testing.T) {
+	var text, js bytes.Buffer
+	l := &EasyLogger{out: newSink(&text), jsonOut: &js}
+	l.EnableStackTrace(true)
+
+	l.Warn("no stack")
+	l.Error("failed") // line 18
+	lines := strings.Split(text.String(), "\n")
+	assert.Contains(t, lines[0], "no stack")
+	assert.Contains(t, lines[1], "failed")
+	assert.Equal(t, "\tgithub.com/joeqian10/EasyLogger/v2.TestEasyLogger_EnableStackTrace()", lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "\t\t/"))
+	assert.True(t, strings.HasSuffix(lines[3], "stack_test.go:18"))
+	assert.NotContains(t, text.String(), "runtime.")
+
+	jsonLines := strings.Split(strings.TrimSpace(js.String()), "\n")
+	assert.Len(t, jsonLines, 2)
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(jsonLines[1]), &m))
+	assert.Equal(t, "stack_test.go:18", m["caller"])
+	assert.True(t, strings.HasPrefix(m["stack"].(string), "github.com/joeqian10/EasyLogger/v2.TestEasyLogger_EnableStackTrace()\n\t"))
+}
+
+func TestEasyLogger_ErrorWithStack(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.ErrorWithStack(errors.New("boom")) // line 38
+	assert.Contains(t, buf.String(), ", boom\n\tgithub.com/joeqian10/EasyLogger/v2.TestEasyLogger_ErrorWithStack()\n\t\t/")
+	assert.Contains(t, buf.String(), "stack_test.go:38\n")
+
+	buf.Reset()
+	l.Error("plain")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	l.SetLevel(FatalLevel)
+	l.ErrorWithStack(errors.New("boom"))
+	assert.Equal(t, "", buf.String())
+}
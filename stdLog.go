@@ -0,0 +1,53 @@
+package easylogger
+
+import (
+	"log"
+	"runtime"
+	"strings"
+)
+
+// StdLogger returns a *log.Logger writing its lines as entries of level, for the libraries
+// logging with the standard library, e.g. the ErrorLog of http.Server or a database driver:
+//
+//	srv := &http.Server{ErrorLog: el.StdLogger(easylogger.ErrorLevel)}
+//
+// The log.Logger has no prefix and no flags, the entries get the header of el, and their caller
+// is the caller of the log.Logger.
+func (this *EasyLogger) StdLogger(level Level) *log.Logger {
+	return log.New(stdLogWriter{el: this, level: level}, "", 0)
+}
+
+// stdLogWriter writes the lines of a log.Logger as entries of level, see StdLogger
+type stdLogWriter struct {
+	el    *EasyLogger
+	level Level
+}
+
+func (w stdLogWriter) Write(p []byte) (int, error) {
+	if !w.el.Enabled(w.level) {
+		return len(p), nil
+	}
+	msg := strings.TrimSuffix(string(p), "\n")
+	if !w.el.needsCaller() && !w.el.wantsStack(w.level) {
+		return len(p), w.el.emitAt(w.level, msg, 0)
+	}
+	// 0 is callers, 1 Write, then the frames of the log package
+	pcs := callers(2)
+	for len(pcs) > 0 && isLogFrame(pcs[0]) {
+		pcs = pcs[1:]
+	}
+	if w.el.wantsStack(w.level) {
+		return len(p), w.el.emitStack(w.level, msg, pcs)
+	}
+	var pc uintptr
+	if len(pcs) > 0 {
+		pc = pcs[0]
+	}
+	return len(p), w.el.emitAt(w.level, msg, pc)
+}
+
+// isLogFrame reports whether the return address pc is in the log package
+func isLogFrame(pc uintptr) bool {
+	f := runtime.FuncForPC(pc - 1)
+	return f != nil && strings.HasPrefix(f.Name(), "log.")
+}
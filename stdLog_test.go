@@ -0,0 +1,26 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEasyLogger_StdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf), colorMode: int32(ColorNever)}
+	l.EnableGID(false)
+	l.SetLevel(InfoLevel)
+
+	std := l.StdLogger(WarnLevel)
+	std.Printf("http: TLS handshake error from %s", "10.0.0.1")
+	assert.Equal(t, "[WARN ] http: TLS handshake error from 10.0.0.1\n", buf.String())
+
+	buf.Reset()
+	l.StdLogger(DebugLevel).Println("filtered")
+	assert.Equal(t, "", buf.String())
+
+	l.EnableCaller(true)
+	std.Print("with caller")
+	assert.Regexp(t, `^\[WARN \] stdLog_test\.go:\d+ v2\.TestEasyLogger_StdLogger\(\) with caller\n$`, buf.String())
+}
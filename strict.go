@@ -0,0 +1,47 @@
+package easylogger
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// SetStrict turns the strict mode on or off. In strict mode the logger panics on the misuses
+// it can detect, e.g. a format string not matching its arguments, an invalid level or an entry
+// logged after Shutdown, so that bugs surface in tests instead of producing garbled lines. It
+// is meant for development only.
+func (this *EasyLogger) SetStrict(strict bool) {
+	var v int32
+	if strict {
+		v = 1
+	}
+	atomic.StoreInt32(&this.strict, v)
+}
+
+// WithStrict turns the strict mode on or off, see SetStrict.
+func WithStrict(strict bool) Option {
+	return func(l *EasyLogger) {
+		l.SetStrict(strict)
+	}
+}
+
+// misuse panics in strict mode, and does nothing otherwise
+func (this *EasyLogger) misuse(format string, a ...interface{}) {
+	if atomic.LoadInt32(&this.strict) == 1 {
+		panic("EasyLogger misuse: " + fmt.Sprintf(format, a...))
+	}
+}
+
+// checkFormat detects the errors reported by fmt in msg, e.g. "%!d(string=x)" or "%!(EXTRA int=1)"
+func (this *EasyLogger) checkFormat(format string, msg string) {
+	if strings.Contains(msg, "%!") && !strings.Contains(format, "%!") {
+		this.misuse("format %q does not match its arguments: %s", format, msg)
+	}
+}
+
+// checkClosed detects the entries logged once the logger or one of its clones is shut down
+func (this *EasyLogger) checkClosed() {
+	if this.out != nil && atomic.LoadInt32(&this.out.closed) == 1 {
+		this.misuse("entry logged after Shutdown or Close")
+	}
+}
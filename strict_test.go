@@ -0,0 +1,43 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEasyLogger_SetStrict(t *testing.T) {
+	l := &EasyLogger{out: newSink(&bytes.Buffer{})}
+	// formats in variables, so that vet does not catch the misuses first
+	number, twoStrings, extra := "%d", "%s %s", "extra"
+
+	// misuses are tolerated by default
+	assert.NotPanics(t, func() { l.Infof(number, "not a number") })
+	assert.NotPanics(t, func() { l.Infof(extra, 1) })
+
+	l.SetStrict(true)
+	assert.NotPanics(t, func() { l.Infof("%d %s", 1, "one") })
+	assert.NotPanics(t, func() { l.Info("100%!") })
+	assert.Panics(t, func() { l.Infof(number, "not a number") })
+	assert.Panics(t, func() { l.Warnf(twoStrings, "missing") })
+	assert.Panics(t, func() { l.Errorf(extra, 1) })
+	assert.Panics(t, func() { l.SetLevel(Level(42)) })
+
+	c := l.Clone(WithStrict(false))
+	assert.NotPanics(t, func() { c.Infof(number, "not a number") })
+}
+
+func TestEasyLogger_StrictAfterShutdown(t *testing.T) {
+	l := &EasyLogger{out: newSink(&bytes.Buffer{})}
+	c := l.With("user", 42)
+	assert.Nil(t, l.Close())
+	// tolerated by default
+	assert.NotPanics(t, func() { c.Info("late") })
+
+	l.SetStrict(true)
+	c.SetStrict(true)
+	assert.Panics(t, func() { l.Info("late") })
+	assert.Panics(t, func() { c.Errorf("late %d", 1) })
+	assert.Panics(t, func() { l.ErrorWithStack(errors.New("late")) })
+}
@@ -0,0 +1,69 @@
+package easylogger
+
+import (
+	"sync/atomic"
+)
+
+// SyncPolicy tells when the log files are committed to stable storage with fsync, for the logs
+// which must survive a crash of the machine, e.g. audit logs. A positive policy syncs every
+// that many entries.
+type SyncPolicy int
+
+const (
+	// SyncNever leaves the writing to the disk to the operating system
+	SyncNever SyncPolicy = 0
+	// SyncEveryWrite syncs after every entry, the slowest but safest policy
+	SyncEveryWrite SyncPolicy = 1
+	// SyncOnError syncs after the ERROR, FATAL and PANIC entries
+	SyncOnError SyncPolicy = -1
+)
+
+// SyncEvery returns the policy syncing every n entries.
+func SyncEvery(n int) SyncPolicy {
+	if n < 1 {
+		return SyncNever
+	}
+	return SyncPolicy(n)
+}
+
+// fileSyncer applies the SyncPolicy of a logger and its clones
+type fileSyncer struct {
+	writes uint64 // accessed atomically
+	policy SyncPolicy
+}
+
+// WithSyncPolicy sets when the log files are synced, the default is SyncNever. The buffered
+// entries, see WithBuffer, are written before. Only the time rotating files of WithDirectory
+// are synced, lumberjack doesn't allow syncing the size rotating files of WithFile.
+func WithSyncPolicy(p SyncPolicy) Option {
+	return func(l *EasyLogger) {
+		if p == SyncNever || p < SyncOnError {
+			l.syncer = nil
+			return
+		}
+		l.syncer = &fileSyncer{policy: p}
+	}
+}
+
+// due reports whether the files must be synced after an entry of level
+func (fs *fileSyncer) due(level Level) bool {
+	if fs.policy == SyncOnError {
+		return level >= ErrorLevel
+	}
+	return atomic.AddUint64(&fs.writes, 1)%uint64(fs.policy) == 0
+}
+
+// syncFiles writes the buffered entries, then syncs the log files, it returns the first error
+func (this *EasyLogger) syncFiles() error {
+	err := this.flushBuffers()
+	for _, f := range this.files {
+		s, ok := f.(interface{ Sync() error })
+		if !ok {
+			continue
+		}
+		if errSync := s.Sync(); err == nil {
+			err = errSync
+		}
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package easylogger
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// syncCounter is a log file counting its syncs
+type syncCounter struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (s *syncCounter) Sync() error {
+	s.syncs++
+	return nil
+}
+
+func TestWithSyncPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy SyncPolicy
+		syncs  int
+	}{
+		{SyncNever, 0},
+		{SyncEveryWrite, 6},
+		{SyncEvery(4), 1},
+		{SyncEvery(0), 0},
+		{SyncOnError, 2},
+	} {
+		f := &syncCounter{}
+		l := &EasyLogger{out: newSink(f), files: []io.Writer{f}}
+		l = l.Clone(WithSyncPolicy(tc.policy))
+		for i := 0; i < 4; i++ {
+			l.Info("info")
+		}
+		l.Error("error")
+		l.With("clone", true).Error("error")
+		assert.Equal(t, tc.syncs, f.syncs)
+	}
+}
+
+func TestLogger_Sync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewEasyLogger(WithDirectory(dir), WithSyncPolicy(SyncEveryWrite))
+	l.Info("synced")
+	assert.Nil(t, l.syncFiles())
+	assert.Nil(t, l.Shutdown(context.Background()))
+	assert.Nil(t, (&Logger{Directory: dir}).Sync())
+}
@@ -0,0 +1,228 @@
+package easylogger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyslogFacility is the facility of the syslog messages, see RFC 5424
+type SyslogFacility int
+
+const (
+	FacilityUser   SyslogFacility = 1
+	FacilityDaemon SyslogFacility = 3
+	FacilityLocal0 SyslogFacility = 16
+	FacilityLocal1 SyslogFacility = 17
+	FacilityLocal2 SyslogFacility = 18
+	FacilityLocal3 SyslogFacility = 19
+	FacilityLocal4 SyslogFacility = 20
+	FacilityLocal5 SyslogFacility = 21
+	FacilityLocal6 SyslogFacility = 22
+	FacilityLocal7 SyslogFacility = 23
+)
+
+// syslogSeverities are the severities of the levels: debug, debug, informational, warning,
+// error, critical and alert
+var syslogSeverities = [...]int{7, 7, 6, 4, 3, 2, 1}
+
+// localSyslogPaths are the sockets of the local syslog daemon, depending on the system
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogConfig configures a SyslogSink
+type SyslogConfig struct {
+	// Network is "udp", "tcp", "unix" or "unixgram", and Address the address of the syslog
+	// server. The default is the local syslog daemon.
+	Network string
+	Address string
+
+	// Facility is the facility of the messages, the default is FacilityUser.
+	Facility SyslogFacility
+
+	// AppName identifies the program, the default is the name of its executable.
+	AppName string
+
+	// Hostname is the host of the messages, the default is os.Hostname.
+	Hostname string
+
+	// DialTimeout is the timeout of the connection, the default is 5 seconds.
+	DialTimeout time.Duration
+}
+
+// errSyslogDown is returned by SyslogSink.WriteEntry while it waits to connect again
+var errSyslogDown = errors.New("syslog server down, waiting to connect again")
+
+// SyslogSink is an EntrySink sending the entries to syslog as RFC 5424 messages, with the
+// severity matching their level. The messages are framed with their length over TCP, see
+// RFC 6587. When a write fails, a background goroutine connects again, backing off according
+// to DefaultRetryPolicy while the server is down, the entries being dropped meanwhile, so that
+// logging never waits for the connection.
+type SyslogSink struct {
+	config SyslogConfig
+
+	mu     sync.Mutex
+	conn   net.Conn // nil while the goroutine of reconnect is connecting
+	closed bool
+	done   chan struct{} // closed by Close, stops reconnect
+}
+
+// NewSyslogSink connects to the syslog server of config, e.g.
+//
+//	s, err := easylogger.NewSyslogSink(easylogger.SyslogConfig{Network: "udp", Address: "logs:514"})
+//	if err == nil {
+//		l.AddSink(s)
+//	}
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	if config.Facility == 0 {
+		config.Facility = FacilityUser
+	}
+	if config.AppName == "" {
+		config.AppName = filepath.Base(os.Args[0])
+	}
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+	conn, err := dialSyslog(config)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{config: config, conn: conn, done: make(chan struct{})}, nil
+}
+
+// dialSyslog connects to the syslog server of config
+func dialSyslog(config SyslogConfig) (net.Conn, error) {
+	if config.Network != "" {
+		return net.DialTimeout(config.Network, config.Address, config.DialTimeout)
+	}
+	for _, path := range localSyslogPaths {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.DialTimeout(network, path, config.DialTimeout); err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, errors.New("no local syslog daemon")
+}
+
+// WriteEntry implements EntrySink.
+func (s *SyslogSink) WriteEntry(e Entry) error {
+	msg := []byte(s.format(e))
+	if s.config.Network == "tcp" {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	return s.write(msg)
+}
+
+// write writes msg to the connection. If the write fails, the connection is closed and
+// reconnect started, msg is dropped as well as the next ones until it connects again.
+func (s *SyslogSink) write(msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrSinkClosed
+	}
+	if s.conn == nil {
+		return errSyslogDown
+	}
+	_, err := s.conn.Write(msg)
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+		go s.reconnect()
+	}
+	return err
+}
+
+// reconnect connects again to the syslog server, backing off after each failure, until it
+// succeeds or the sink is closed.
+func (s *SyslogSink) reconnect() {
+	for failures := 1; ; failures++ {
+		conn, err := dialSyslog(s.config)
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err == nil {
+			s.conn = conn
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-time.After(DefaultRetryPolicy.Backoff(failures)):
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// format renders e as a RFC 5424 message:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+func (s *SyslogSink) format(e Entry) string {
+	severity := syslogSeverities[ErrorLevel]
+	if e.Level >= TraceLevel && e.Level <= PanicLevel {
+		severity = syslogSeverities[e.Level]
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%d>1 %s %s %s %d - - ", int(s.config.Facility)*8+severity,
+		e.Time.Format(time.RFC3339Nano), syslogName(s.config.Hostname, 255),
+		syslogName(s.config.AppName, 48), os.Getpid())
+	if e.GID != 0 {
+		// no GID with EnableGID(false)
+		fmt.Fprintf(&sb, "GID %d, ", e.GID)
+	}
+	sb.WriteString(e.Message)
+	if len(e.Fields) > 0 {
+		sb.WriteByte(' ')
+		sb.WriteString(e.Fields.String())
+	}
+	return sb.String()
+}
+
+// syslogName makes name a valid header field of at most max characters, "-" if empty
+func syslogName(name string, max int) string {
+	name = strings.Map(func(c rune) rune {
+		if c <= ' ' || c > '~' {
+			return '_'
+		}
+		return c
+	}, name)
+	if len(name) > max {
+		name = name[:max]
+	}
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+// Close closes the connection to the syslog server.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		close(s.done)
+	}
+	s.closed = true
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,105 @@
+package easylogger
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSyslogSink_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer pc.Close()
+
+	s, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: pc.LocalAddr().String(),
+		Facility: FacilityLocal3, AppName: "my app", Hostname: "host"})
+	assert.Nil(t, err)
+	defer s.Close()
+	l := &EasyLogger{out: newSink(newMultiWriter())}
+	l.AddSink(s)
+	l.With("user", 42).Warn("hello")
+
+	buf := make([]byte, 1024)
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	assert.Nil(t, err)
+	// local3 * 8 + warning
+	re := `^<156>1 \S+ host my_app ` + strconv.Itoa(os.Getpid()) + ` - - GID \d+, hello user=42$`
+	assert.Regexp(t, regexp.MustCompile(re), string(buf[:n]))
+
+	l.EnableGID(false)
+	l.Warn("no gid")
+	n, _, err = pc.ReadFrom(buf)
+	assert.Nil(t, err)
+	assert.Regexp(t, regexp.MustCompile(` - - no gid$`), string(buf[:n]))
+}
+
+func TestSyslogSink_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+
+	s, err := NewSyslogSink(SyslogConfig{Network: "tcp", Address: ln.Addr().String()})
+	assert.Nil(t, err)
+	defer s.Close()
+	conn, err := ln.Accept()
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	assert.Nil(t, s.WriteEntry(Entry{Time: time.Now(), Level: FatalLevel, Message: "down"}))
+	// framed with the length, user * 8 + critical
+	r := bufio.NewReader(conn)
+	length, err := r.ReadString(' ')
+	assert.Nil(t, err)
+	n, err := strconv.Atoi(length[:len(length)-1])
+	assert.Nil(t, err)
+	msg := make([]byte, n)
+	_, err = io.ReadFull(r, msg)
+	assert.Nil(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^<10>1 .* - - down$`), string(msg))
+}
+
+func TestSyslogSink_Reconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	addr := ln.Addr().String()
+	s, err := NewSyslogSink(SyslogConfig{Network: "tcp", Address: addr, DialTimeout: time.Second})
+	assert.Nil(t, err)
+	defer s.Close()
+	conn, err := ln.Accept()
+	assert.Nil(t, err)
+
+	// the server goes away
+	conn.Close()
+	ln.Close()
+	e := Entry{Time: time.Now(), Level: InfoLevel, Message: "lost"}
+	for i := 0; i < 10 && s.WriteEntry(e) == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// dropped while connecting again in the background, without waiting
+	assert.Equal(t, errSyslogDown, s.WriteEntry(e))
+
+	ln, err = net.Listen("tcp", addr)
+	assert.Nil(t, err)
+	defer ln.Close()
+	// connected again in the background
+	assert.Eventually(t, func() bool {
+		return s.WriteEntry(Entry{Time: time.Now(), Level: InfoLevel, Message: "back"}) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	conn, err = ln.Accept()
+	assert.Nil(t, err)
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('k')
+	assert.Nil(t, err)
+	assert.Contains(t, line, " - - back")
+
+	assert.Nil(t, s.Close())
+	assert.Equal(t, ErrSinkClosed, s.WriteEntry(e))
+}
@@ -1,7 +1,8 @@
-package EasyLogger
+package easylogger
 
 import (
-	"compress/gzip"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,20 +20,41 @@ const (
 	DefaultLogDir      = "./Logs/"
 	FileNameTimeFormat = "2006-01-02"
 	FileNameExt        = ".log"
+	JSONFileNameExt    = ".jsonl"
+	ErrorFileNameExt   = ".err" + FileNameExt
 	CompressSuffix     = ".gz"
 
+	// file name time formats used when RotationInterval is less than a day or an hour
+	HourFileNameTimeFormat   = "2006-01-02_15"
+	MinuteFileNameTimeFormat = "2006-01-02_15-04"
+
 	NanosecondPerDay = 24 * 3600 * time.Second
 )
 
 var (
 	osStat = os.Stat
+
+	// currentTime exists so it can be mocked out by tests
+	currentTime = time.Now
+
+	// writeFile exists so it can be mocked out by tests
+	writeFile = (*os.File).Write
+
+	// megabyte is the conversion factor between MaxSize and bytes, a variable so tests can mock it out
+	megabyte = 1024 * 1024
+
+	// reopenCheckInterval is the minimum delay between two checks of ReopenIfMoved
+	reopenCheckInterval = time.Second
 )
 
 // ensure we always implement io.WriteCloser
 var _ io.WriteCloser = (*Logger)(nil)
 
 // this aims to have a time rotating logger depending on days
-
+//
+// Write, Touch, Rotate, Reconfigure and Close can be called from any goroutine, in any order:
+// a Write after Close opens the log file again. The exported fields must not be modified
+// once the Logger is in use, except with Reconfigure.
 type Logger struct {
 	// Directory is the place to store log files.
 	// Default is "./Logs/"
@@ -41,10 +64,37 @@ type Logger struct {
 	// The default is not rotating.
 	MaxDays int
 
+	// RotationInterval is the period covered by each file, e.g. time.Hour or
+	// 15*time.Minute, it takes precedence over MaxDays and is at least a minute.
+	// Files are named after the start of their period, including the hour for
+	// intervals less than a day and the minute for intervals less than an hour.
+	RotationInterval time.Duration
+
+	// MaxSize is the maximum size in megabytes of a log file. When a file would grow
+	// beyond it, the next file of the same period is opened, numbered from 1 before
+	// the extension, e.g. "2021-09-01.1.log". The default is no limit.
+	MaxSize int
+
+	// MaxLinesPerFile is the maximum number of entries of a log file, the next file of the
+	// same period is opened after it like after MaxSize, e.g. to bound the work of the batch
+	// processors reading the files. The entries of a file written before the Logger opened it
+	// are counted as its lines. The default is no limit.
+	MaxLinesPerFile int
+
 	// MaxBackups is the maximum number of files to retain.
 	// The default is to retain all old files.
 	MaxBackups int
 
+	// MaxAge is the maximum number of days to retain old files, based on the
+	// timestamp in their name, whatever MaxBackups. The default is not to remove
+	// old files based on their age.
+	MaxAge int
+
+	// MaxTotalSize is the maximum combined size in megabytes of the log files,
+	// compressed or not. The oldest files are removed once it is exceeded, the
+	// current file is always kept. The default is no limit.
+	MaxTotalSize int
+
 	// LocalTime determines if the time used for formatting the timestamps in
 	// backup files is the computer's local time.  The default is to use UTC
 	// time.
@@ -54,26 +104,161 @@ type Logger struct {
 	// using gzip. The default is not to perform compression.
 	Compress bool
 
+	// Compression is the compression of the rotated log files, "gzip", "none" or
+	// the name of a Compressor added with RegisterCompressor such as "zstd" or
+	// "lz4". It takes precedence over Compress.
+	Compression string
+
+	// FileExt is the extension of the log files.
+	// The default is ".log".
+	FileExt string
+
+	// FileMode is the mode of the log files created by the Logger, whatever the umask. The
+	// default is 0644, restricted by the umask. The new files of a rotation keep the owner and
+	// the group of the previous file, the compressed files those of the original file.
+	FileMode os.FileMode
+
+	// DirMode is the mode of the Directory if the Logger creates it, restricted by the umask.
+	// The default is 0766.
+	DirMode os.FileMode
+
+	// NetworkFS tunes the Logger for the log files on network filesystems such as NFS: the
+	// writes failing with ESTALE or EIO are retried according to NetworkRetry, and the file
+	// is opened again when its handle is stale.
+	NetworkFS bool
+
+	// NetworkRetry is the RetryPolicy of the NetworkFS mode, DefaultRetryPolicy if not set.
+	NetworkRetry RetryPolicy
+
+	// OnError is called with the errors returned by Write, once the NetworkFS retries are
+	// used up. It is called without the Logger locked.
+	OnError func(err error)
+
+	// Shared coordinates the Logger with the Loggers of other processes writing into the same
+	// Directory with the same FileExt: the writes and the retention and compression passes
+	// hold an advisory lock on a lock file of the Directory, flock on Unix and LockFileEx on
+	// Windows, and the size of the current file is read again before each write, so that the
+	// processes rotate together and never write past MaxSize. MaxLinesPerFile only counts the
+	// entries written by each Logger.
+	Shared bool
+
+	// ReopenIfMoved makes the Logger check, at most once a second when writing, that the
+	// current file is still at its path, and reopen the path if another program renamed or
+	// removed the file, instead of writing into the moved or unlinked file.
+	ReopenIfMoved bool
+
 	currentFile *os.File
+	start       time.Time   // start of the period of the currentFile
+	seq         int         // number of the currentFile within its period
+	size        int64       // size of the currentFile
+	lines       int         // number of entries in the currentFile, counted if MaxLinesPerFile is set
+	rotations   int         // number of rotations, see Rotations
+	periodEnd   time.Time   // when the currentFile must be rotated, zero if never
+	timer       *time.Timer // rotates the currentFile at periodEnd even if nothing is written
+	timerGen    int         // number of the current timer, to ignore the stale ones
 	mu          sync.Mutex
-	millCh      chan bool
-	startMill   sync.Once
+	millCh      chan bool   // requests a pass of the mill goroutine, nil while it is not running
+	millPending int         // number of mill passes requested and not done yet
+	millIdle    *sync.Cond  // signaled on mu once millPending drops to zero, see Wait
+	millMu      sync.Mutex  // serializes the mill passes
+	final       bool        // set on the copy running the last mill pass, which compresses the latest file too
+	lockFile    *os.File    // the lock file of Shared, opened by the first Write
+	lastCheck   time.Time   // time of the last check of ReopenIfMoved
+	owner       os.FileInfo // the previous log file, whose owner the new files keep
+	shutdown    bool        // set by Shutdown, the files are not opened again afterwards
 }
 
-// Close implements io.Closer, and closes the current logfile.
+// ErrLoggerShutdown is returned by the writes of a Logger after its Shutdown, which would
+// otherwise open the log file again.
+var ErrLoggerShutdown error = &LoggerError{Code: CodeWrite, Err: errors.New("log file shut down, entry dropped")}
+
+// Close implements io.Closer, and closes the current logfile. It also stops the rotation at
+// the end of the period and the mill goroutine once its passes are done, which resume with the
+// next Write. Use Wait to wait for the passes.
 func (l *Logger) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.stopMill()
+	if l.lockFile != nil {
+		l.lockFile.Close()
+		l.lockFile = nil
+	}
 	return l.close()
 }
 
+// Wait waits for the retention and compression passes requested so far to be done, including
+// the last one of Shutdown.
+func (l *Logger) Wait() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.millIdle == nil {
+		l.millIdle = sync.NewCond(&l.mu)
+	}
+	for l.millPending > 0 {
+		l.millIdle.Wait()
+	}
+}
+
+// Shutdown closes the current log file like Close and stops the mill goroutine, then runs a
+// last retention and compression pass which also compresses the file just closed, instead of
+// leaving it to the next start. If ctx is done first, Shutdown returns ctx.Err() and the pass
+// goes on in the background, see Wait. The writes after Shutdown fail with ErrLoggerShutdown.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.shutdown = true
+	err := l.close()
+	c := l.config()
+	l.stopMill()
+	if err == nil {
+		l.millPending++
+	}
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	c.final = true
+
+	done := make(chan error, 1)
+	go func() {
+		defer l.millDone()
+		l.millMu.Lock()
+		defer l.millMu.Unlock()
+		done <- c.millRunOnce()
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkFile verifies that the open currentFile, if any, is still usable.
+func (l *Logger) checkFile() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.currentFile == nil {
+		return nil
+	}
+	if _, err := l.currentFile.Stat(); err != nil {
+		return fmt.Errorf("invalid log file handle: %s", err)
+	}
+	return nil
+}
+
 // close method closes the currentFile if it is open.
 func (l *Logger) close() error {
 	if l.currentFile == nil {
 		return nil
 	}
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
 	err := l.currentFile.Close()
 	l.currentFile = nil
+	openFiles.closed(l)
 	return err
 }
 
@@ -82,7 +267,7 @@ func (l *Logger) dir() string {
 	fi, err := osStat(l.Directory)
 	if err != nil {
 		if os.IsNotExist(err) {
-			err2 := os.MkdirAll(l.Directory, 0766)
+			err2 := os.MkdirAll(l.Directory, l.dirMode())
 			if err2 != nil {
 				l.Directory = DefaultLogDir
 			}
@@ -96,35 +281,100 @@ func (l *Logger) dir() string {
 }
 
 // mill performs post-rotation compression and removal of stale log files,
-// starting the mill goroutine if necessary.
+// starting the mill goroutine if necessary, l.mu must be locked.
 func (l *Logger) mill() {
-	l.startMill.Do(func() {
+	if l.millCh == nil {
 		l.millCh = make(chan bool, 1)
-		go l.millRun()
-	})
+		go l.millRun(l.millCh)
+	}
 	select {
 	case l.millCh <- true:
+		l.millPending++
 	default:
+		// a pass is already waiting
+	}
+}
+
+// stopMill makes the mill goroutine exit once the requested passes are done, l.mu must be locked
+func (l *Logger) stopMill() {
+	if l.millCh != nil {
+		close(l.millCh)
+		l.millCh = nil
 	}
 }
 
 // millRun runs in a goroutine to manage post-rotation compression and removal
-// of old log files.
-func (l *Logger) millRun() {
-	for range l.millCh {
-		// what am I going to do, log this?
-		_ = l.millRunOnce()
+// of old log files, until millCh is closed.
+func (l *Logger) millRun(millCh <-chan bool) {
+	for range millCh {
+		// work on a copy of the configuration, the Logger may be written to or reconfigured meanwhile
+		l.mu.Lock()
+		c := l.config()
+		l.mu.Unlock()
+		l.millMu.Lock()
+		err := c.millRunOnce()
+		l.millMu.Unlock()
+		if err != nil {
+			diagf(codeOf(err, CodeRetention), "retention or compression in %s failed: %v", c.Directory, err)
+		}
+		l.millDone()
+	}
+}
+
+// millDone counts a mill pass as done
+func (l *Logger) millDone() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.millPending--
+	if l.millPending == 0 && l.millIdle != nil {
+		l.millIdle.Broadcast()
+	}
+}
+
+// config returns a Logger with the configuration of l, without its state
+func (l *Logger) config() *Logger {
+	return &Logger{
+		Directory:        l.dir(),
+		MaxDays:          l.MaxDays,
+		RotationInterval: l.RotationInterval,
+		MaxSize:          l.MaxSize,
+		MaxLinesPerFile:  l.MaxLinesPerFile,
+		MaxBackups:       l.MaxBackups,
+		MaxAge:           l.MaxAge,
+		MaxTotalSize:     l.MaxTotalSize,
+		LocalTime:        l.LocalTime,
+		Compress:         l.Compress,
+		Compression:      l.Compression,
+		FileExt:          l.FileExt,
+		FileMode:         l.FileMode,
+		DirMode:          l.DirMode,
+		NetworkFS:        l.NetworkFS,
+		NetworkRetry:     l.NetworkRetry,
+		OnError:          l.OnError,
+		Shared:           l.Shared,
+		ReopenIfMoved:    l.ReopenIfMoved,
 	}
 }
 
 // millRunOnce performs compression and removal of stale log files.
 // Log files are compressed if enabled via configuration and old log
 // files are removed, keeping at most l.MaxBackups files, as long as
-// none of them are older than MaxAge.
+// none of them are older than MaxAge. It is called on a copy of the
+// configuration, see config.
 func (l *Logger) millRunOnce() error {
-	if l.MaxBackups == 0 && !l.Compress {
+	compression := l.compression()
+	if l.MaxBackups == 0 && l.MaxAge == 0 && l.MaxTotalSize == 0 && compression == "" {
 		return nil
 	}
+	if l.Shared {
+		// l is a copy, its lock file is closed with the pass
+		unlock, err := l.lockShared()
+		if err != nil {
+			return err
+		}
+		defer l.lockFile.Close()
+		defer unlock()
+	}
 
 	files, err := l.oldLogFiles() // will get all log files including the latest writing one
 	if err != nil {
@@ -139,10 +389,7 @@ func (l *Logger) millRunOnce() error {
 		for _, f := range files {
 			// Only count the uncompressed log file or the
 			// compressed log file, not both.
-			fn := f.Name()
-			if strings.HasSuffix(fn, CompressSuffix) {
-				fn = fn[:len(fn)-len(CompressSuffix)]
-			}
+			fn := strings.TrimSuffix(f.Name(), compressedExt(f.Name()))
 			preserved[fn] = true
 
 			if len(preserved) > l.MaxBackups {
@@ -153,13 +400,50 @@ func (l *Logger) millRunOnce() error {
 		}
 		files = remaining
 	}
+	if l.MaxAge > 0 {
+		cutoff := l.now().Add(-time.Duration(l.MaxAge) * NanosecondPerDay)
+		var remaining []logInfo
+		for i, f := range files {
+			// a file is old once its period is over, and never the latest file, it may be the current one
+			if i > 0 && f.timestamp.Add(l.interval()).Before(cutoff) {
+				remove = append(remove, f)
+			} else {
+				remaining = append(remaining, f)
+			}
+		}
+		files = remaining
+	}
+	if l.MaxTotalSize > 0 {
+		budget := int64(l.MaxTotalSize) * int64(megabyte)
+		var total int64
+		var remaining []logInfo
+		for i, f := range files {
+			total += f.Size()
+			if i > 0 && total > budget {
+				remove = append(remove, f)
+			} else {
+				remaining = append(remaining, f)
+			}
+		}
+		files = remaining
+	}
 
-	if l.Compress {
-		temp := files[1:]
-		for _, f := range temp {
-			if !strings.HasSuffix(f.Name(), CompressSuffix) {
-				compress = append(compress, f)
+	// an unknown compression is reported once the stale files are removed
+	var c Compressor
+	if compression != "" && len(files) > 0 {
+		if c, err = compressorByName(compression); err == nil {
+			// the other processes may still write into the latest file of Shared
+			temp := files[1:]
+			if l.final && !l.Shared {
+				temp = files
+			}
+			for _, f := range temp {
+				if compressedExt(f.Name()) == "" {
+					compress = append(compress, f)
+				}
 			}
+		} else {
+			err = coded(CodeCompression, err)
 		}
 	}
 
@@ -169,9 +453,18 @@ func (l *Logger) millRunOnce() error {
 			err = errRemove
 		}
 	}
-	for _, f := range compress {
-		fn := filepath.Join(l.dir(), f.Name())
-		errCompress := compressLogFile(fn, fn+CompressSuffix)
+	// the files are compressed concurrently, within the limits of the compression pool
+	errs := make([]error, len(compress))
+	var wg sync.WaitGroup
+	for i, f := range compress {
+		wg.Add(1)
+		go func(i int, fn string) {
+			defer wg.Done()
+			errs[i] = compressPooled(fn, fn+c.Ext(), c)
+		}(i, filepath.Join(l.dir(), f.Name()))
+	}
+	wg.Wait()
+	for _, errCompress := range errs {
 		if err == nil && errCompress != nil {
 			err = errCompress
 		}
@@ -180,15 +473,79 @@ func (l *Logger) millRunOnce() error {
 	return err
 }
 
-// newFileName creates a new file name
-func (l *Logger) newFileName() string {
-	t := time.Now()
-	if !l.LocalTime {
-		t = t.UTC()
+// newFileName creates the name of the file number seq of the period starting at start
+func (l *Logger) newFileName(start time.Time, seq int) string {
+	name := start.Format(l.timeFormat())
+	if seq > 0 {
+		name += "." + strconv.Itoa(seq)
+	}
+	return filepath.Join(l.dir(), name+l.fileExt())
+}
+
+// interval returns the period covered by each file, zero means no rotation
+func (l *Logger) interval() time.Duration {
+	if l.RotationInterval > 0 {
+		if l.RotationInterval < time.Minute {
+			return time.Minute
+		}
+		return l.RotationInterval
+	}
+	return time.Duration(l.MaxDays) * NanosecondPerDay
+}
+
+// timeFormat returns the time format of the file names
+func (l *Logger) timeFormat() string {
+	switch {
+	case l.RotationInterval <= 0 || l.interval() >= NanosecondPerDay:
+		return FileNameTimeFormat
+	case l.interval() >= time.Hour:
+		return HourFileNameTimeFormat
+	default:
+		return MinuteFileNameTimeFormat
+	}
+}
+
+func (l *Logger) location() *time.Location {
+	if l.LocalTime {
+		return time.Local
+	}
+	return time.UTC
+}
+
+func (l *Logger) now() time.Time {
+	return currentTime().In(l.location())
+}
+
+// periodStart returns the start of the period t belongs to, aligned on the wall clock
+func (l *Logger) periodStart(t time.Time) time.Time {
+	if l.timeFormat() == FileNameTimeFormat {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
 	}
-	currentDate := t.Format(FileNameTimeFormat)
-	name := currentDate + FileNameExt
-	return filepath.Join(l.dir(), name)
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(l.interval()).Add(-shift)
+}
+
+// compression returns the name of the compression of the rotated files, "" if they are not compressed
+func (l *Logger) compression() string {
+	switch {
+	case l.Compression == CompressionNone:
+		return ""
+	case l.Compression != "":
+		return l.Compression
+	case l.Compress:
+		return CompressionGzip
+	default:
+		return ""
+	}
+}
+
+func (l *Logger) fileExt() string {
+	if l.FileExt == "" {
+		return FileNameExt
+	}
+	return l.FileExt
 }
 
 // oldLogFiles returns the list of all log files stored in the same
@@ -204,12 +561,8 @@ func (l *Logger) oldLogFiles() ([]logInfo, error) {
 		if f.IsDir() {
 			continue
 		}
-		if t, err := l.timeFromName(f.Name(), FileNameExt); err == nil {
-			logFiles = append(logFiles, logInfo{t, f})
-			continue
-		}
-		if t, err := l.timeFromName(f.Name(), FileNameExt+CompressSuffix); err == nil {
-			logFiles = append(logFiles, logInfo{t, f})
+		if t, seq, err := l.timeFromName(f.Name(), l.fileExt()+compressedExt(f.Name())); err == nil {
+			logFiles = append(logFiles, logInfo{t, seq, f})
 			continue
 		}
 		// error parsing means that the suffix at the end was not generated
@@ -222,23 +575,115 @@ func (l *Logger) oldLogFiles() ([]logInfo, error) {
 	return logFiles, nil
 }
 
-// openNew opens a new log currentFile for writing, moving any old log currentFile out of the
-// way.  This methods assumes the currentFile has already been closed.
-func (l *Logger) openNew() error {
-	newFileName := l.newFileName()
+// openNew opens the file number seq of the period starting at start for writing,
+// appending to it if it already exists. If the file was already compressed, it rolls to the
+// next numbered sibling instead, e.g. "2021-09-01.2.log" when "2021-09-01.1.log.gz" exists.
+// This methods assumes the currentFile has already been closed.
+func (l *Logger) openNew(start time.Time, seq int) error {
+	newFileName := l.newFileName(start, seq)
+	for compressedExists(newFileName) || l.isFull(newFileName) {
+		seq++
+		newFileName = l.newFileName(start, seq)
+	}
 
-	// we use truncate here because this should only get called when we've moved
-	// the currentFile ourselves. if someone else creates the currentFile in the meantime,
-	// just wipe out the contents.
-	f, err := os.OpenFile(newFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	// never truncate here: the file may have been created by another Logger or by
+	// a previous run of the program on the same day, we must not wipe out its contents,
+	// it is appended to unless it is already full.
+	f, err := l.openLogFile(newFileName)
 	if err != nil {
 		return fmt.Errorf("can't open new logfile: %s", err)
 	}
-	l.currentFile = f
+	l.setCurrentFile(f, start, seq)
 	l.mill()
 	return nil
 }
 
+// isFull reports whether the file name exists and reached MaxSize, e.g. written by another
+// process, so that the next file of the sequence must be used
+func (l *Logger) isFull(name string) bool {
+	if l.MaxSize <= 0 {
+		return false
+	}
+	info, err := osStat(name)
+	return err == nil && info.Size() >= l.maxSize()
+}
+
+// openLogFile opens name to append to it, creating it with FileMode and the owner of the
+// previous log file if it doesn't exist
+func (l *Logger) openLogFile(name string) (*os.File, error) {
+	_, errStat := osStat(name)
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, l.fileMode())
+	if err != nil || !os.IsNotExist(errStat) {
+		return f, err
+	}
+	if l.FileMode != 0 {
+		if err := f.Chmod(l.FileMode); err != nil {
+			diagf(CodeRotation, "mode of %s not set: %v", name, err)
+		}
+	}
+	if l.owner != nil {
+		if err := chown(name, l.owner); err != nil {
+			diagf(CodeRotation, "owner of %s not preserved: %v", name, err)
+		}
+	}
+	return f, nil
+}
+
+func (l *Logger) fileMode() os.FileMode {
+	if l.FileMode == 0 {
+		return 0644
+	}
+	return l.FileMode
+}
+
+func (l *Logger) dirMode() os.FileMode {
+	if l.DirMode == 0 {
+		return 0766
+	}
+	return l.DirMode
+}
+
+// reopenIfMoved opens the path of the currentFile again if it no longer leads to the file, it is
+// left closed if that fails
+func (l *Logger) reopenIfMoved() {
+	now := l.now()
+	if now.Sub(l.lastCheck) < reopenCheckInterval {
+		return
+	}
+	l.lastCheck = now
+	name := l.currentFile.Name()
+	fi, err := l.currentFile.Stat()
+	if err != nil {
+		return
+	}
+	if cur, err := osStat(name); err == nil && os.SameFile(fi, cur) {
+		return
+	}
+	start, seq := l.start, l.seq
+	l.close()
+	f, err := l.openLogFile(name)
+	if err != nil {
+		diagf(CodeRotation, "moved log file %s not reopened: %v", name, err)
+		return
+	}
+	l.setCurrentFile(f, start, seq)
+}
+
+// lockShared locks the lock file of Shared, opening it if needed
+func (l *Logger) lockShared() (unlock func(), err error) {
+	if l.lockFile == nil {
+		name := filepath.Join(l.dir(), "."+strings.TrimPrefix(l.fileExt(), ".")+".lock")
+		if l.lockFile, err = os.OpenFile(name, os.O_CREATE|os.O_RDWR, l.fileMode()); err != nil {
+			return nil, fmt.Errorf("can't open lock file: %s", err)
+		}
+	}
+	f := l.lockFile
+	if err = lockExclusive(f); err != nil {
+		return nil, fmt.Errorf("can't lock %s: %s", f.Name(), err)
+	}
+	return func() { unlockFile(f) }, nil
+}
+
 // openExistingOrNew opens the logfile if its timestamp is in the log interval.
 // If there is no such currentFile, a new currentFile is created.
 func (l *Logger) openExistingOrNew() error {
@@ -248,52 +693,343 @@ func (l *Logger) openExistingOrNew() error {
 	}
 	if len(allFiles) > 0 {
 		latest := allFiles[0]
-		t := time.Now()
-		if !l.LocalTime {
-			t = t.UTC()
-		}
-		duration := t.Sub(latest.timestamp)
-		if duration < time.Duration(l.MaxDays)*NanosecondPerDay {
+		duration := l.now().Sub(latest.timestamp)
+		if duration < l.interval() {
+			if l.MaxSize > 0 && latest.Size() >= l.maxSize() || compressedExt(latest.Name()) != "" {
+				return l.openNew(latest.timestamp, latest.seq+1)
+			}
 			// use the latest file to log
-			file, err := os.OpenFile(l.dir()+latest.Name(), os.O_APPEND|os.O_WRONLY, 0644)
+			file, err := os.OpenFile(filepath.Join(l.dir(), latest.Name()), os.O_APPEND|os.O_WRONLY, l.fileMode())
 			if err != nil {
 				return err
 			}
-			l.currentFile = file
+			l.setCurrentFile(file, latest.timestamp, latest.seq)
 			return nil
 		}
 	}
 	// create a new file
-	return l.openNew()
+	if len(allFiles) > 0 && l.owner == nil {
+		l.owner = allFiles[0].FileInfo
+	}
+	return l.openNew(l.periodStart(l.now()), 0)
+}
+
+// countFileLines returns the number of lines of the file name, 0 if it can't be read
+func countFileLines(name string) int {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	lines := 0
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.Read(buf)
+		lines += bytes.Count(buf[:n], []byte{'\n'})
+		if err != nil {
+			return lines
+		}
+	}
 }
 
-// timeFromName extracts the formatted time from the filename by stripping off
-// the filename's prefix and extension. This prevents someone's filename from
-// confusing time.parse.
-func (l *Logger) timeFromName(filename string, ext string) (time.Time, error) {
+// setCurrentFile makes f, the file number seq of the period starting at start, the currentFile
+func (l *Logger) setCurrentFile(f *os.File, start time.Time, seq int) {
+	l.currentFile = f
+	l.start = start
+	l.seq = seq
+	l.size = 0
+	if fi, err := f.Stat(); err == nil {
+		l.size = fi.Size()
+		l.owner = fi
+	}
+	l.lastCheck = l.now()
+	l.lines = 0
+	if l.MaxLinesPerFile > 0 && l.size > 0 {
+		l.lines = countFileLines(f.Name())
+	}
+	if i := l.interval(); i > 0 {
+		l.periodEnd = start.Add(i)
+	} else {
+		l.periodEnd = time.Time{}
+	}
+	l.armTimer()
+	openFiles.opened(l)
+}
+
+// armTimer schedules the rotation of the currentFile at periodEnd, so that the file of the
+// next period is created on time for the tools tailing or cleaning the directory.
+func (l *Logger) armTimer() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	if l.periodEnd.IsZero() {
+		return
+	}
+	l.timerGen++
+	gen := l.timerGen
+	l.timer = time.AfterFunc(l.periodEnd.Sub(l.now()), func() {
+		l.rotateOnTimer(gen)
+	})
+}
+
+// rotateOnTimer opens the file of the new period when the timer number gen fires, unless
+// the timer has been stopped or replaced meanwhile.
+func (l *Logger) rotateOnTimer(gen int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.timer == nil || gen != l.timerGen || l.currentFile == nil {
+		return
+	}
+	if l.now().Before(l.periodEnd) {
+		// the timer fired early, e.g. after the clock was set back
+		l.armTimer()
+		return
+	}
+	if err := l.close(); err != nil {
+		diagf(CodeRotation, "rotation in %s failed: %v", l.Directory, err)
+		return
+	}
+	l.rotations++
+	// on failure the next Write tries again
+	if err := l.openExistingOrNew(); err != nil {
+		diagf(CodeRotation, "rotation in %s failed: %v", l.Directory, err)
+	}
+}
+
+func (l *Logger) maxSize() int64 {
+	return int64(l.MaxSize) * int64(megabyte)
+}
+
+// timeFromName extracts the formatted time, and the file number within the period if any,
+// from the filename by stripping off the filename's prefix and extension. This prevents
+// someone's filename from confusing time.parse.
+func (l *Logger) timeFromName(filename string, ext string) (time.Time, int, error) {
 	if !strings.HasSuffix(filename, ext) {
-		return time.Time{}, errors.New("mismatched extension")
+		return time.Time{}, 0, errors.New("mismatched extension")
 	}
 	ts := filename[:len(filename)-len(ext)]
-	return time.Parse(FileNameTimeFormat, ts)
+	seq := 0
+	if i := strings.LastIndexByte(ts, '.'); i >= 0 {
+		n, err := strconv.Atoi(ts[i+1:])
+		if err != nil || n < 1 {
+			return time.Time{}, 0, errors.New("invalid file number")
+		}
+		ts, seq = ts[:i], n
+	}
+	t, err := time.ParseInLocation(l.timeFormat(), ts, l.location())
+	return t, seq, err
 }
 
-func (l *Logger) Write(p []byte) (n int, err error) {
+// Touch creates the current log file if it does not exist yet, for tools which need the file
+// to exist before anything is logged. Otherwise the file is created on the first Write.
+func (l *Logger) Touch() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.shutdown {
+		return ErrLoggerShutdown
+	}
+	if l.currentFile == nil {
+		return l.openExistingOrNew()
+	}
+	return nil
+}
+
+// Rotate closes the current log file and opens the next one, numbered after it within the
+// same period, e.g. "2021-09-01.1.log" after "2021-09-01.log". It is meant for operators and
+// external tools which need to force a rotation.
+func (l *Logger) Rotate() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	if l.shutdown {
+		return ErrLoggerShutdown
+	}
 	if l.currentFile == nil {
-		if err = l.openExistingOrNew(); err != nil {
+		if err := l.openExistingOrNew(); err != nil {
+			return err
+		}
+	}
+	start, seq := l.start, l.seq+1
+	if now := l.now(); !l.periodEnd.IsZero() && !now.Before(l.periodEnd) {
+		start, seq = l.periodStart(now), 0
+	}
+	if err := l.close(); err != nil {
+		return err
+	}
+	l.rotations++
+	return l.openNew(start, seq)
+}
+
+// Rotations returns the number of rotations of the log files, at the end of the periods, at
+// MaxSize or MaxLinesPerFile, and by Rotate.
+func (l *Logger) Rotations() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rotations
+}
+
+// Size returns the size of the current log file, 0 if none is open.
+func (l *Logger) Size() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.currentFile == nil {
+		return 0
+	}
+	return l.size
+}
+
+// Sync commits the current log file to stable storage, see os.File.Sync.
+func (l *Logger) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.currentFile == nil {
+		return nil
+	}
+	return l.currentFile.Sync()
+}
+
+// Reconfigure changes the configuration of the Logger while it is in use. update is called
+// with the Logger locked and must only set its exported fields. The current log file is
+// closed, the next Write opens the file matching the new configuration.
+func (l *Logger) Reconfigure(update func(l *Logger)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	update(l)
+	return l.close()
+}
+
+func (l *Logger) Write(p []byte) (n int, err error) {
+	return l.writeEntries(p, []int{len(p)})
+}
+
+// writeEntries writes the entries of p, ending at the offsets ends, rotating the file between
+// them when needed. The entries going into the same file are written at once.
+func (l *Logger) writeEntries(p []byte, ends []int) (n int, err error) {
+	defer func() {
+		if err != nil && l.OnError != nil {
+			l.OnError(err)
+		}
+	}()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.shutdown {
+		return 0, ErrLoggerShutdown
+	}
+	if l.Shared {
+		unlock, err := l.lockShared()
+		if err != nil {
 			return 0, err
 		}
+		defer unlock()
+		if l.currentFile != nil {
+			// the other processes may have written into the file since
+			if fi, err := l.currentFile.Stat(); err == nil {
+				l.size = fi.Size()
+			}
+		}
+	}
+	if l.ReopenIfMoved && l.currentFile != nil {
+		l.reopenIfMoved()
+	}
+
+	// p[from:to] holds the entries not written yet, lines of them
+	from, to, lines := 0, 0, 0
+	flush := func() error {
+		if from == to {
+			return nil
+		}
+		openFiles.touched(l)
+		m, err := writeFile(l.currentFile, p[from:to])
+		if err != nil && l.NetworkFS {
+			m, err = l.retryWrite(p[from:to], m, err)
+		}
+		l.size += int64(m)
+		l.lines += lines
+		n += m
+		from, lines = to, 0
+		return err
 	}
-	n, err = l.currentFile.Write(p)
-	return n, err
+	for _, end := range ends {
+		if l.currentFile != nil && !l.periodEnd.IsZero() && !l.now().Before(l.periodEnd) {
+			if err = flush(); err != nil {
+				return n, err
+			}
+			if err = l.close(); err != nil {
+				return n, err
+			}
+			l.rotations++
+		}
+		if l.currentFile == nil {
+			if err = l.openExistingOrNew(); err != nil {
+				return n, err
+			}
+		}
+		size := l.size + int64(to-from)
+		if l.MaxSize > 0 && size > 0 && size+int64(end-to) > l.maxSize() ||
+			l.MaxLinesPerFile > 0 && l.lines+lines >= l.MaxLinesPerFile {
+			if err = flush(); err != nil {
+				return n, err
+			}
+			start, seq := l.start, l.seq+1
+			if err = l.close(); err != nil {
+				return n, err
+			}
+			l.rotations++
+			if err = l.openNew(start, seq); err != nil {
+				return n, err
+			}
+		}
+		to = end
+		lines++
+	}
+	return n, flush()
+}
+
+// retryWrite retries the write of p[n:] which failed with err, according to NetworkRetry.
+// The currentFile is opened again if its handle is stale.
+func (l *Logger) retryWrite(p []byte, n int, err error) (int, error) {
+	policy := l.NetworkRetry
+	if policy.MaxAttempts == 0 {
+		policy = DefaultRetryPolicy
+	}
+	policy.Retryable = isTransientFSError
+	if !isTransientFSError(err) || policy.MaxAttempts < 2 {
+		return n, err
+	}
+
+	first := true
+	errRetry := policy.Do(context.Background(), func() error {
+		if first {
+			// the first attempt already failed with err
+			first = false
+			return err
+		}
+		if isStaleHandle(err) {
+			start, seq := l.start, l.seq
+			l.close() // the stale handle can't be closed cleanly
+			if err = l.openNew(start, seq); err != nil {
+				return err
+			}
+			diagf(CodeWrite, "log file handle of %s was stale, opened again", l.Directory)
+		}
+		var m int
+		m, err = writeFile(l.currentFile, p[n:])
+		n += m
+		return err
+	})
+	return n, errRetry
 }
 
-// compressLogFile compresses the given log file, removing the
+// compressLogFile compresses the given log file with c, removing the
 // uncompressed log file if successful.
-func compressLogFile(src, dst string) (err error) {
+func compressLogFile(src, dst string, c Compressor) (err error) {
 	f, err := os.Open(src)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %v", err)
@@ -305,10 +1041,6 @@ func compressLogFile(src, dst string) (err error) {
 		return fmt.Errorf("failed to stat log file: %v", err)
 	}
 
-	if err := chown(dst, fi); err != nil {
-		return fmt.Errorf("failed to chown compressed log file: %v", err)
-	}
-
 	// If this file already exists, we presume it was created by
 	// a previous attempt to compress the log file.
 	gzf, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fi.Mode())
@@ -317,7 +1049,10 @@ func compressLogFile(src, dst string) (err error) {
 	}
 	defer gzf.Close()
 
-	gz := gzip.NewWriter(gzf)
+	if err := chown(dst, fi); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to chown compressed log file: %v", err)
+	}
 
 	defer func() {
 		if err != nil {
@@ -326,7 +1061,11 @@ func compressLogFile(src, dst string) (err error) {
 		}
 	}()
 
-	if _, err := io.Copy(gz, f); err != nil {
+	gz, err := c.NewWriter(gzf)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(gz, rateLimited(f)); err != nil {
 		return err
 	}
 	if err := gz.Close(); err != nil {
@@ -350,6 +1089,7 @@ func compressLogFile(src, dst string) (err error) {
 // timestamp.
 type logInfo struct {
 	timestamp time.Time
+	seq       int
 	os.FileInfo
 }
 
@@ -357,6 +1097,9 @@ type logInfo struct {
 type byFormatTime []logInfo
 
 func (b byFormatTime) Less(i, j int) bool {
+	if b[i].timestamp.Equal(b[j].timestamp) {
+		return b[i].seq < b[j].seq
+	}
 	return b[i].timestamp.Before(b[j].timestamp)
 }
 
@@ -1,7 +1,20 @@
-package EasyLogger
+package easylogger
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -18,3 +31,649 @@ func TestDuration(t *testing.T) {
 	d := nn.Sub(a)
 	assert.Equal(t, NanosecondPerDay, d)
 }
+
+func TestLogger_Touch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := &Logger{Directory: dir, MaxDays: 1}
+	defer l.Close()
+	fileName := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt)
+
+	// the file is only created on first write
+	_, err = os.Stat(fileName)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Nil(t, l.Touch())
+	fi, err := os.Stat(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), fi.Size())
+}
+
+func TestLogger_SharedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	const lines = 1000
+	fileName := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt)
+	assert.Nil(t, ioutil.WriteFile(fileName, []byte("written before\n"), 0644))
+
+	// MaxDays 0 makes both Loggers go through openNew, which must not truncate
+	l1 := &Logger{Directory: dir}
+	l2 := &Logger{Directory: dir}
+	var wg sync.WaitGroup
+	for i, l := range []*Logger{l1, l2} {
+		wg.Add(1)
+		go func(i int, l *Logger) {
+			defer wg.Done()
+			for j := 0; j < lines; j++ {
+				_, err := l.Write([]byte(fmt.Sprintf("logger %d line %d\n", i, j)))
+				assert.Nil(t, err)
+			}
+		}(i, l)
+	}
+	wg.Wait()
+	assert.Nil(t, l1.Close())
+	assert.Nil(t, l2.Close())
+
+	b, err := ioutil.ReadFile(fileName)
+	assert.Nil(t, err)
+	all := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Equal(t, 2*lines+1, len(all))
+	assert.Equal(t, "written before", all[0])
+	seen := make(map[string]bool)
+	for _, line := range all[1:] {
+		seen[line] = true
+	}
+	assert.Equal(t, 2*lines, len(seen))
+}
+
+func TestLogger_Shared(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	megabyte = 1
+	defer func() {
+		currentTime = time.Now
+		megabyte = 1024 * 1024
+	}()
+
+	// like two processes, each Logger sees the writes of the other one
+	l1 := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10, Shared: true}
+	l2 := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10, Shared: true}
+	defer l1.Close()
+	defer l2.Close()
+	for i, s := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n"} {
+		_, err := []*Logger{l1, l2}[i%2].Write([]byte(s))
+		assert.Nil(t, err)
+	}
+
+	for name, content := range map[string]string{
+		"2021-09-01.log":   "aaaa\nbbbb\n",
+		"2021-09-01.1.log": "cccc\ndddd\n",
+		"2021-09-01.2.log": "eeee\n",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	_, err = os.Stat(filepath.Join(dir, ".log.lock"))
+	assert.Nil(t, err)
+}
+
+func TestLogger_ReopenIfMoved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	defer func() { currentTime = time.Now }()
+
+	l := &Logger{Directory: dir, ReopenIfMoved: true}
+	defer l.Close()
+	_, err = l.Write([]byte("aaaa\n"))
+	assert.Nil(t, err)
+	name := filepath.Join(dir, "2021-09-01"+FileNameExt)
+	assert.Nil(t, os.Rename(name, name+".moved"))
+	// not checked again within reopenCheckInterval
+	_, err = l.Write([]byte("aaaa\n"))
+	assert.Nil(t, err)
+	now = now.Add(reopenCheckInterval)
+	_, err = l.Write([]byte("bbbb\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, os.Remove(name))
+	now = now.Add(reopenCheckInterval)
+	_, err = l.Write([]byte("cccc\n"))
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(name + ".moved")
+	assert.Nil(t, err)
+	assert.Equal(t, "aaaa\naaaa\n", string(b))
+	b, err = ioutil.ReadFile(name)
+	assert.Nil(t, err)
+	assert.Equal(t, "cccc\n", string(b))
+	assert.Equal(t, 0, l.Rotations())
+}
+
+func TestLogger_FileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no permission bits on Windows")
+	}
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := &Logger{Directory: filepath.Join(dir, "logs"), FileMode: 0660, DirMode: 0750}
+	defer l.Close()
+	_, err = l.Write([]byte("aaaa\n"))
+	assert.Nil(t, err)
+
+	fi, err := os.Stat(filepath.Join(dir, "logs"))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0750), fi.Mode().Perm()&0750)
+	fi, err = os.Stat(filepath.Join(dir, "logs", time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0660), fi.Mode().Perm())
+}
+
+func TestLogger_RotationInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	defer func() { currentTime = time.Now }()
+
+	l := &Logger{Directory: dir, RotationInterval: 15 * time.Minute}
+	defer l.Close()
+	assert.Equal(t, MinuteFileNameTimeFormat, l.timeFormat())
+
+	l.Write([]byte("first\n"))
+	now = now.Add(time.Minute) // 14:59, same period
+	l.Write([]byte("second\n"))
+	now = now.Add(time.Minute) // 15:00, next period
+	l.Write([]byte("third\n"))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "2021-09-01_14-45.log"))
+	assert.Nil(t, err)
+	assert.Equal(t, "first\nsecond\n", string(b))
+	b, err = ioutil.ReadFile(filepath.Join(dir, "2021-09-01_15-00.log"))
+	assert.Nil(t, err)
+	assert.Equal(t, "third\n", string(b))
+
+	// a new Logger keeps appending to the file of the current period
+	assert.Nil(t, l.Close())
+	l2 := &Logger{Directory: dir, RotationInterval: 15 * time.Minute}
+	defer l2.Close()
+	l2.Write([]byte("fourth\n"))
+	b, _ = ioutil.ReadFile(filepath.Join(dir, "2021-09-01_15-00.log"))
+	assert.Equal(t, "third\nfourth\n", string(b))
+
+	h := &Logger{RotationInterval: time.Hour}
+	assert.Equal(t, HourFileNameTimeFormat, h.timeFormat())
+	assert.Equal(t, time.Date(2021, 9, 1, 15, 0, 0, 0, time.UTC), h.periodStart(now))
+	d := &Logger{MaxDays: 1}
+	assert.Equal(t, FileNameTimeFormat, d.timeFormat())
+}
+
+func TestLogger_MaxSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	megabyte = 1
+	defer func() {
+		currentTime = time.Now
+		megabyte = 1024 * 1024
+	}()
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10}
+	defer l.Close()
+	for _, s := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeeeeeeeeeeeeee\n"} {
+		_, err := l.Write([]byte(s))
+		assert.Nil(t, err)
+	}
+
+	for name, content := range map[string]string{
+		"2021-09-01.log":   "aaaa\nbbbb\n",
+		"2021-09-01.1.log": "cccc\ndddd\n",
+		"2021-09-01.2.log": "eeeeeeeeeeeeeee\n",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	assert.Equal(t, 2, l.Rotations())
+	assert.Equal(t, int64(16), l.Size())
+
+	// a new Logger picks up the latest file, moving on as it is full
+	assert.Nil(t, l.Close())
+	l2 := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10}
+	defer l2.Close()
+	l2.Write([]byte("ffff\n"))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "2021-09-01.3.log"))
+	assert.Nil(t, err)
+	assert.Equal(t, "ffff\n", string(b))
+
+	// the day boundary starts over from the unnumbered file
+	now = now.Add(24 * time.Hour)
+	l2.Write([]byte("gggg\n"))
+	b, err = ioutil.ReadFile(filepath.Join(dir, "2021-09-02.log"))
+	assert.Nil(t, err)
+	assert.Equal(t, "gggg\n", string(b))
+
+	files, err := l2.oldLogFiles()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(files))
+	assert.Equal(t, "2021-09-02.log", files[0].Name())
+	assert.Equal(t, "2021-09-01.3.log", files[1].Name())
+	assert.Equal(t, "2021-09-01.log", files[4].Name())
+}
+
+func TestLogger_OpenNewSkipsFullFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	megabyte = 1
+	defer func() {
+		currentTime = time.Now
+		megabyte = 1024 * 1024
+	}()
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxSize: 10}
+	defer l.Close()
+	_, err = l.Write([]byte("aaaa\n"))
+	assert.Nil(t, err)
+	// meanwhile another process filled the next file of the sequence
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "2021-09-01.1.log"), []byte("other process\n"), 0644))
+	assert.Nil(t, l.Rotate())
+	_, err = l.Write([]byte("bbbb\n"))
+	assert.Nil(t, err)
+
+	for name, content := range map[string]string{
+		"2021-09-01.log":   "aaaa\n",
+		"2021-09-01.1.log": "other process\n",
+		"2021-09-01.2.log": "bbbb\n",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(b))
+	}
+}
+
+func TestLogger_RotateOnTimer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// 100ms before the end of a minute
+	base, realStart := time.Date(2021, 9, 1, 14, 58, 59, 900000000, time.UTC), time.Now()
+	currentTime = func() time.Time { return base.Add(time.Since(realStart)) }
+	defer func() { currentTime = time.Now }()
+
+	l := &Logger{Directory: dir, RotationInterval: time.Minute}
+	_, err = l.Write([]byte("before\n"))
+	assert.Nil(t, err)
+
+	// the file of the next minute is created without any Write
+	next := filepath.Join(dir, "2021-09-01_14-59.log")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err = os.Stat(next); err == nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Nil(t, err)
+
+	_, err = l.Write([]byte("after\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, l.Close())
+	b, err := ioutil.ReadFile(next)
+	assert.Nil(t, err)
+	assert.Equal(t, "after\n", string(b))
+	assert.Nil(t, l.timer)
+}
+
+func TestLogger_Rotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 1, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	defer func() { currentTime = time.Now }()
+
+	l := &Logger{Directory: dir, MaxDays: 1}
+	defer l.Close()
+	l.Write([]byte("a\n"))
+	assert.Nil(t, l.Rotate())
+	l.Write([]byte("b\n"))
+	assert.Nil(t, l.Rotate())
+	l.Write([]byte("c\n"))
+
+	for name, content := range map[string]string{
+		"2021-09-01.log":   "a\n",
+		"2021-09-01.1.log": "b\n",
+		"2021-09-01.2.log": "c\n",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(b))
+	}
+
+	// after the end of the period, the first file of the new period
+	now = now.Add(24 * time.Hour)
+	assert.Nil(t, l.Rotate())
+	l.Write([]byte("d\n"))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "2021-09-02.log"))
+	assert.Nil(t, err)
+	assert.Equal(t, "d\n", string(b))
+}
+
+// TestLogger_Concurrency checks with the race detector that Write, Touch, Rotate, Reconfigure
+// and Close can be called concurrently, and that no line is lost meanwhile.
+func TestLogger_Concurrency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	megabyte = 1
+	defer func() { megabyte = 1024 * 1024 }()
+
+	l := &Logger{Directory: dir, MaxDays: 1, Compress: true}
+	stop := make(chan struct{})
+	var wg, writers sync.WaitGroup
+	var written int64
+	for i := 0; i < 4; i++ {
+		writers.Add(1)
+		go func(i int) {
+			defer writers.Done()
+			for j := 0; j < 500; j++ {
+				if _, err := l.Write([]byte(fmt.Sprintf("writer %d line %d\n", i, j))); err == nil {
+					atomic.AddInt64(&written, 1)
+				}
+			}
+		}(i)
+	}
+	chaos := []func(i int){
+		func(int) { l.Touch() },
+		func(int) { l.Rotate() },
+		func(int) { l.Close() },
+		func(i int) {
+			l.Reconfigure(func(l *Logger) {
+				l.MaxSize = (i % 2) * 64
+				l.Compress = i%3 != 0
+			})
+		},
+	}
+	for _, f := range chaos {
+		wg.Add(1)
+		go func(f func(int)) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				f(i)
+				time.Sleep(time.Millisecond)
+			}
+		}(f)
+	}
+	writers.Wait()
+	close(stop)
+	wg.Wait()
+	assert.Nil(t, l.Close())
+
+	assert.Equal(t, int64(2000), written)
+
+	// the mill may still be compressing, count the lines until they are all there
+	var lines int
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if lines, err = countLines(l); err == nil && lines == 2000 {
+			break
+		}
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, lines)
+}
+
+// countLines counts the lines of the plain and compressed log files of l
+func countLines(l *Logger) (int, error) {
+	l.mu.Lock()
+	files, err := l.config().oldLogFiles()
+	l.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	lines := 0
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(l.Directory, f.Name()))
+		if err != nil {
+			return 0, err
+		}
+		if strings.HasSuffix(f.Name(), CompressSuffix) {
+			gz, err := gzip.NewReader(bytes.NewReader(b))
+			if err != nil {
+				return 0, err
+			}
+			if b, err = ioutil.ReadAll(gz); err != nil {
+				return 0, err
+			}
+		}
+		lines += strings.Count(string(b), "\n")
+	}
+	return lines, nil
+}
+
+func TestLogger_MaxAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2021, 9, 10, 14, 58, 0, 0, time.UTC)
+	currentTime = func() time.Time { return now }
+	defer func() { currentTime = time.Now }()
+
+	for _, name := range []string{"2021-09-01.log", "2021-09-02.1.log.gz", "2021-09-07.log", "2021-09-09.log", "other.txt"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644))
+	}
+	l := &Logger{Directory: dir, MaxDays: 1, MaxAge: 3}
+	assert.Nil(t, l.config().millRunOnce())
+
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	var names []string
+	for _, fi := range infos {
+		names = append(names, fi.Name())
+	}
+	assert.Equal(t, []string{"2021-09-07.log", "2021-09-09.log", "other.txt"}, names)
+}
+
+func TestLogger_MaxTotalSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	megabyte = 1
+	defer func() { megabyte = 1024 * 1024 }()
+
+	for name, size := range map[string]int{
+		"2021-09-01.log":    40,
+		"2021-09-02.log.gz": 30,
+		"2021-09-03.log":    30,
+		"2021-09-03.1.log":  30,
+		"2021-09-04.log":    200, // the current file is kept even if it is too big
+	} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644))
+	}
+	l := &Logger{Directory: dir, MaxDays: 1, MaxTotalSize: 100}
+	assert.Nil(t, l.config().millRunOnce())
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(infos))
+
+	l.MaxTotalSize = 300
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "2021-09-01.log"), make([]byte, 60), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "2021-09-02.log"), make([]byte, 60), 0644))
+	assert.Nil(t, l.config().millRunOnce())
+	infos, err = ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	var names []string
+	for _, fi := range infos {
+		names = append(names, fi.Name())
+	}
+	assert.Equal(t, []string{"2021-09-02.log", "2021-09-04.log"}, names)
+}
+
+func TestLogger_CompressedCollision(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// a previous run on the same day rotated and compressed its files
+	name := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat))
+	assert.Nil(t, ioutil.WriteFile(name+FileNameExt+CompressSuffix, []byte("gz 0"), 0644))
+	assert.Nil(t, ioutil.WriteFile(name+".1"+FileNameExt+CompressSuffix, []byte("gz 1"), 0644))
+
+	l := &Logger{Directory: dir, MaxDays: 1}
+	_, err = l.Write([]byte("restarted\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, l.seq)
+	assert.Nil(t, l.Close())
+
+	b, err := ioutil.ReadFile(name + ".2" + FileNameExt)
+	assert.Nil(t, err)
+	assert.Equal(t, "restarted\n", string(b))
+	b, err = ioutil.ReadFile(name + ".1" + FileNameExt + CompressSuffix)
+	assert.Nil(t, err)
+	assert.Equal(t, "gz 1", string(b))
+
+	// openNew rolls over the compressed files as well
+	l = &Logger{Directory: dir}
+	assert.Nil(t, l.openNew(l.periodStart(time.Now()), 0))
+	assert.Equal(t, 2, l.seq)
+	assert.Nil(t, l.Close())
+}
+
+func TestLogger_MaxLinesPerFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat))
+	assert.Nil(t, ioutil.WriteFile(name+FileNameExt, []byte("before 1\nbefore 2\n"), 0644))
+
+	l := &Logger{Directory: dir, MaxDays: 1, MaxLinesPerFile: 3}
+	for i := 0; i < 5; i++ {
+		_, err = l.Write([]byte("line " + strconv.Itoa(i) + "\n"))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, l.Close())
+
+	b, err := ioutil.ReadFile(name + FileNameExt)
+	assert.Nil(t, err)
+	assert.Equal(t, "before 1\nbefore 2\nline 0\n", string(b))
+	b, err = ioutil.ReadFile(name + ".1" + FileNameExt)
+	assert.Nil(t, err)
+	assert.Equal(t, "line 1\nline 2\nline 3\n", string(b))
+	b, err = ioutil.ReadFile(name + ".2" + FileNameExt)
+	assert.Nil(t, err)
+	assert.Equal(t, "line 4\n", string(b))
+}
+
+func TestLogger_Shutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := &Logger{Directory: dir, MaxDays: 1, Compress: true}
+	_, err = l.Write([]byte("last words\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, l.Shutdown(context.Background()))
+
+	name := filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt)
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+	n, err := countLines(l)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	// the file is not opened again
+	_, err = l.Write([]byte("too late\n"))
+	assert.Equal(t, ErrLoggerShutdown, err)
+	assert.Equal(t, ErrLoggerShutdown, l.Rotate())
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+
+	// the deadline is honored
+	gate := make(chan struct{})
+	RegisterCompressor("gated", gatedCompressor{gate})
+	l = &Logger{Directory: dir, MaxDays: 1, Compression: "gated", FileExt: ".gated"}
+	_, err = l.Write([]byte("again\n"))
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Shutdown(ctx))
+	// the pass goes on in the background
+	close(gate)
+	l.Wait()
+	_, err = os.Stat(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+".gated.raw"))
+	assert.Nil(t, err)
+}
+
+func TestLogger_CloseStopsMill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easylogger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := &Logger{Directory: dir, MaxDays: 1, Compress: true}
+	_, err = l.Write([]byte("first\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, l.Rotate())
+	assert.NotNil(t, l.millCh)
+	assert.Nil(t, l.Close())
+	assert.Nil(t, l.millCh)
+
+	// the passes requested before Close are done
+	l.Wait()
+	assert.Equal(t, 0, l.millPending)
+	_, err = os.Stat(filepath.Join(dir, time.Now().UTC().Format(FileNameTimeFormat)+FileNameExt+CompressSuffix))
+	assert.Nil(t, err)
+
+	// the mill goroutine starts again with the next file
+	assert.Nil(t, l.Rotate())
+	assert.NotNil(t, l.millCh)
+	assert.Nil(t, l.Close())
+	l.Wait()
+}
+
+// gatedCompressor copies once the gate is closed
+type gatedCompressor struct {
+	gate chan struct{}
+}
+
+func (c gatedCompressor) Ext() string { return ".raw" }
+
+func (c gatedCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	<-c.gate
+	return nopWriteCloser{w}, nil
+}
+
+func (c gatedCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(r), nil
+}
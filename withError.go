@@ -0,0 +1,53 @@
+package easylogger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrorChainField is the field of the messages of the errors wrapped by the error of WithError
+const ErrorChainField = "error_chain"
+
+// WithError returns a child logger adding err to all its entries in the "error" field, and the
+// messages of the errors it wraps, see errors.Unwrap, in the "error_chain" field, e.g.
+//
+//	l.WithError(err).Error("config not loaded")
+//
+// logs, with err wrapping os.ErrNotExist,
+//
+//	[ERROR] GID 1, config not loaded error="load: file does not exist" error_chain="[load: file does not exist file does not exist]"
+//
+// When an error of the chain has a stack trace, like the errors of github.com/pkg/errors, err
+// formatted with %+v is rendered below the entries like the stack traces of EnableStackTrace,
+// instead of the stack trace of the call.
+func (this *EasyLogger) WithError(err error) *EasyLogger {
+	if err == nil {
+		return this
+	}
+	fields := Fields{ErrorField: err}
+	var chain []string
+	withStack := false
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		chain = append(chain, e.Error())
+		withStack = withStack || hasStackTrace(e)
+	}
+	if len(chain) > 1 {
+		fields[ErrorChainField] = chain
+	}
+	c := this.WithFields(fields)
+	if withStack {
+		c.errStack = fmt.Sprintf("%+v", err)
+	}
+	return c
+}
+
+// hasStackTrace reports whether err has a stack trace it renders with %+v, like the errors of
+// github.com/pkg/errors
+func hasStackTrace(err error) bool {
+	if _, ok := err.(fmt.Formatter); !ok {
+		return false
+	}
+	_, ok := reflect.TypeOf(err).MethodByName("StackTrace")
+	return ok
+}
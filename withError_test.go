@@ -0,0 +1,63 @@
+package easylogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// stackError is an error with a stack trace, like the errors of github.com/pkg/errors
+type stackError struct {
+	msg   string
+	cause error
+}
+
+func (e *stackError) Error() string { return e.msg + ": " + e.cause.Error() }
+
+func (e *stackError) Unwrap() error { return e.cause }
+
+func (e *stackError) StackTrace() []uintptr { return nil }
+
+func (e *stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		io.WriteString(s, e.Error()+"\nmain.load\n\t/src/config.go:12")
+		return
+	}
+	io.WriteString(s, e.Error())
+}
+
+func TestEasyLogger_WithError(t *testing.T) {
+	var text, js bytes.Buffer
+	l := &EasyLogger{out: newSink(&text), jsonOut: &js}
+	assert.Equal(t, l, l.WithError(nil))
+
+	err := fmt.Errorf("load: %w", os.ErrNotExist)
+	l.WithError(err).Error("config not loaded")
+	assert.Contains(t, text.String(), `config not loaded error="load: file does not exist" error_chain="[load: file does not exist file does not exist]"`)
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(js.Bytes(), &m))
+	assert.Equal(t, "load: file does not exist", m[ErrorField])
+	assert.Equal(t, []interface{}{"load: file does not exist", "file does not exist"}, m[ErrorChainField])
+	assert.Nil(t, m["stack"])
+
+	// a single error has no chain
+	text.Reset()
+	l.WithError(errors.New("boom")).Warn("failed")
+	assert.Contains(t, text.String(), "failed error=boom\n")
+
+	// the stack trace of the error is rendered like the stack traces of the calls
+	text.Reset()
+	l.EnableStackTrace(true)
+	l.WithError(&stackError{"load", os.ErrNotExist}).Error("config not loaded")
+	lines := strings.Split(text.String(), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "\tload: file does not exist", lines[1])
+	assert.Equal(t, "\tmain.load", lines[2])
+	assert.Equal(t, "\t\t/src/config.go:12", lines[3])
+}
@@ -0,0 +1,97 @@
+package easylogger
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// WriteFailurePolicy tells what happens to the entries which can't be written to any output,
+// e.g. when the disk of the log files is full or a file can't be opened. The failures are
+// counted by Stats and GetErrorStats and reported to the handler of SetErrorHandler whatever
+// the policy.
+type WriteFailurePolicy int32
+
+const (
+	// FailureMirror mirrors the ERROR and FATAL entries to stderr at a bounded rate, the default
+	FailureMirror WriteFailurePolicy = iota
+	// FailureStderr writes all the entries to stderr instead
+	FailureStderr
+	// FailureDrop drops the entries silently
+	FailureDrop
+	// FailureBlock retries writing the entries, blocking the callers, or the async writer,
+	// meanwhile. It gives up after FailureBlockRetries retries, or once the logger is shut down,
+	// or its Shutdown deadline expires in asynchronous mode, the entry is then counted as failed.
+	FailureBlock
+)
+
+// FailureBlockRetries is the maximum number of retries of an entry with FailureBlock, about
+// two minutes
+const FailureBlockRetries = 120
+
+// the delays between the retries of FailureBlock, doubling from the first to the last one
+var (
+	failureRetryFirst = 10 * time.Millisecond
+	failureRetryMax   = time.Second
+)
+
+// SetWriteFailurePolicy sets what happens to the entries which can't be written to any output,
+// it is safe to call at any time.
+func (this *EasyLogger) SetWriteFailurePolicy(p WriteFailurePolicy) {
+	atomic.StoreInt32(&this.writeFailure, int32(p))
+}
+
+// WithWriteFailurePolicy sets what happens to the entries which can't be written to any output.
+func WithWriteFailurePolicy(p WriteFailurePolicy) Option {
+	return func(l *EasyLogger) {
+		l.SetWriteFailurePolicy(p)
+	}
+}
+
+// GetWriteFailurePolicy returns what happens to the entries which can't be written to any output.
+func (this *EasyLogger) GetWriteFailurePolicy() WriteFailurePolicy {
+	return WriteFailurePolicy(atomic.LoadInt32(&this.writeFailure))
+}
+
+// retryWrite writes the rendered record to the outputs until one of them succeeds, it returns
+// the number of bytes written, and the last error if it gives up, see FailureBlock
+func (this *EasyLogger) retryWrite(r *record) (int, error) {
+	delay, err := failureRetryFirst, r.err
+	for i := 0; i < FailureBlockRetries && !this.retryAbandoned(); i++ {
+		time.Sleep(delay)
+		if delay *= 2; delay > failureRetryMax {
+			delay = failureRetryMax
+		}
+		var n int
+		if n, err = this.writeLineTo(this.out, r, this.colorOut()); err == nil {
+			return n, nil
+		}
+		if r.json != nil && this.jsonOut != nil {
+			if n, err = this.jsonOut.Write(r.json); err == nil {
+				return n, nil
+			}
+		}
+	}
+	return 0, err
+}
+
+// retryAbandoned reports whether the retries of FailureBlock are to stop: once the logger is
+// shut down, or in asynchronous mode once Shutdown stops waiting for the queued entries
+func (this *EasyLogger) retryAbandoned() bool {
+	if this.async != nil {
+		return atomic.LoadInt32(&this.async.abandoned) == 1
+	}
+	return this.out != nil && atomic.LoadInt32(&this.out.closed) == 1
+}
+
+// writeFailed handles the record which couldn't be written to any output, following the
+// WriteFailurePolicy
+func (this *EasyLogger) writeFailed(r *record) {
+	switch this.GetWriteFailurePolicy() {
+	case FailureMirror:
+		mirrorToStderr(r.Level, r.GID, r.Message)
+	case FailureStderr:
+		fallback.mu.Lock()
+		defer fallback.mu.Unlock()
+		stderr.Write(this.renderLine(r, false))
+	}
+}
@@ -0,0 +1,104 @@
+package easylogger
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyWriter fails its first writes
+type flakyWriter struct {
+	buf      bytes.Buffer
+	failures int32
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if atomic.AddInt32(&w.failures, -1) >= 0 {
+		return 0, errors.New("no space left on device")
+	}
+	return w.buf.Write(p)
+}
+
+func TestEasyLogger_SetWriteFailurePolicy(t *testing.T) {
+	var buf bytes.Buffer
+	stderr = &buf
+	fallback = &stderrMirror{}
+	defer func() {
+		stderr = os.Stderr
+		fallback = &stderrMirror{}
+	}()
+
+	l := &EasyLogger{out: newSink(failingWriter{}), colorMode: int32(ColorNever), metrics: &logMetrics{}}
+	l.EnableGID(false)
+	assert.Equal(t, FailureMirror, l.GetWriteFailurePolicy())
+	l.Info("info")
+	l.Error("error")
+	assert.NotContains(t, buf.String(), "info")
+	assert.Contains(t, buf.String(), FallbackPrefix)
+	assert.Contains(t, buf.String(), "error")
+
+	buf.Reset()
+	l.SetWriteFailurePolicy(FailureStderr)
+	l.Info("info")
+	assert.Equal(t, "[INFO ] info\n", buf.String())
+
+	buf.Reset()
+	l.SetWriteFailurePolicy(FailureDrop)
+	l.Error("error")
+	assert.Equal(t, "", buf.String())
+	assert.Equal(t, uint64(4), l.Stats().Errors)
+}
+
+func TestEasyLogger_WriteFailureBlock(t *testing.T) {
+	failureRetryFirst, failureRetryMax = time.Millisecond, 2*time.Millisecond
+	defer func() {
+		failureRetryFirst, failureRetryMax = 10*time.Millisecond, time.Second
+	}()
+
+	w := &flakyWriter{failures: 3}
+	l := &EasyLogger{out: newSink(w), metrics: &logMetrics{}}
+	WithWriteFailurePolicy(FailureBlock)(l)
+	l.Info("blocked")
+	assert.Contains(t, w.buf.String(), "blocked")
+	assert.Equal(t, int32(-1), atomic.LoadInt32(&w.failures))
+	stats := l.Stats()
+	assert.Equal(t, uint64(0), stats.Errors)
+	assert.Equal(t, uint64(1), stats.Entries[InfoLevel])
+}
+
+func TestEasyLogger_WriteFailureBlockGivesUp(t *testing.T) {
+	failureRetryFirst, failureRetryMax = time.Millisecond, time.Millisecond
+	defer func() {
+		failureRetryFirst, failureRetryMax = 10*time.Millisecond, time.Second
+	}()
+
+	// after FailureBlockRetries
+	w := &flakyWriter{failures: 1 << 30}
+	l := &EasyLogger{out: newSink(w), metrics: &logMetrics{}}
+	WithWriteFailurePolicy(FailureBlock)(l)
+	l.Info("lost")
+	assert.Equal(t, int32(1<<30-1-FailureBlockRetries), atomic.LoadInt32(&w.failures))
+	assert.Equal(t, uint64(1), l.Stats().Errors)
+
+	// once the logger is shut down
+	failureRetryMax = 10 * time.Millisecond
+	l = &EasyLogger{out: newSink(&flakyWriter{failures: 1 << 30}), metrics: &logMetrics{}}
+	WithWriteFailurePolicy(FailureBlock)(l)
+	done := make(chan struct{})
+	go func() {
+		l.Info("lost")
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, l.Close())
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("still retrying after Close")
+	}
+	assert.Equal(t, uint64(1), l.Stats().Errors)
+}
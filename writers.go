@@ -0,0 +1,59 @@
+package easylogger
+
+import "io"
+
+// AddWriter adds w to the outputs of the logger, it receives the same lines as the log files.
+// The logger and its clones share their outputs, so w is added to all of them.
+func (this *EasyLogger) AddWriter(w io.Writer) {
+	if this.out == nil {
+		this.out = newSink(newMultiWriter())
+	}
+	this.out.add(w)
+}
+
+// RemoveWriter removes w from the outputs of the logger, it returns false if w is not an output.
+// Once it returns, w doesn't receive any more lines.
+func (this *EasyLogger) RemoveWriter(w io.Writer) bool {
+	if this.out == nil {
+		return false
+	}
+	return this.out.remove(w)
+}
+
+// add adds w to the writers of the sink
+func (s *sink) add(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mw, ok := s.w.(*multiWriter)
+	if !ok {
+		mw = newMultiWriter(s.w)
+		s.w = mw
+	}
+	mw.writers = append(mw.writers, w)
+}
+
+// remove removes w from the writers of the sink
+func (s *sink) remove(w io.Writer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == w {
+		s.w = newMultiWriter()
+		return true
+	}
+	mw, ok := s.w.(*multiWriter)
+	if !ok {
+		return false
+	}
+	for i, x := range mw.writers {
+		if lw, ok := x.(*levelWriter); ok {
+			x = lw.Writer
+		}
+		if x == w {
+			mw.writers = append(mw.writers[:i], mw.writers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
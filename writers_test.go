@@ -0,0 +1,36 @@
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEasyLogger_AddWriter(t *testing.T) {
+	var out, added bytes.Buffer
+	l := &EasyLogger{out: newSink(&out)}
+	c := l.With("k", "v")
+
+	l.AddWriter(&added)
+	c.Info("both")
+	assert.Contains(t, out.String(), "both k=v")
+	assert.Contains(t, added.String(), "both k=v")
+
+	assert.True(t, l.RemoveWriter(&added))
+	assert.False(t, l.RemoveWriter(&added))
+	l.Info("out only")
+	assert.Contains(t, out.String(), "out only")
+	assert.NotContains(t, added.String(), "out only")
+
+	// an entry is not lost while one output still works
+	assert.True(t, l.RemoveWriter(&out))
+	l.AddWriter(failingWriter{})
+	l.AddWriter(&added)
+	assert.Nil(t, l.output(InfoLevel, "added only"))
+	assert.Contains(t, added.String(), "added only")
+
+	var e EasyLogger
+	e.AddWriter(&added)
+	e.Info("zero value")
+	assert.Contains(t, added.String(), "zero value")
+}
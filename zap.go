@@ -0,0 +1,96 @@
+// +build zap
+
+package easylogger
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// zapCore is a zapcore.Core writing the entries with an EasyLogger, see AsZapCore
+type zapCore struct {
+	el *EasyLogger
+}
+
+// AsZapCore returns a zapcore.Core writing the entries of a zap logger with this logger, so
+// that the code using zap gets the rotating files without changing its calls. Build with
+// -tags zap:
+//
+//	logger := zap.New(el.AsZapCore(), zap.AddCaller())
+//
+// The zap fields become fields. DPANIC entries are logged as ERROR, FATAL and PANIC entries are
+// only logged, zap exits or panics itself.
+func (this *EasyLogger) AsZapCore() zapcore.Core {
+	return &zapCore{el: this}
+}
+
+// zapLevel returns the Level of the zap level l
+func zapLevel(l zapcore.Level) Level {
+	switch {
+	case l < zapcore.InfoLevel:
+		return DebugLevel
+	case l == zapcore.InfoLevel:
+		return InfoLevel
+	case l == zapcore.WarnLevel:
+		return WarnLevel
+	case l <= zapcore.DPanicLevel:
+		return ErrorLevel
+	case l == zapcore.PanicLevel:
+		return PanicLevel
+	}
+	return FatalLevel
+}
+
+// zapFields returns the zap fields as Fields, nil if there are none
+func zapFields(fs []zapcore.Field) Fields {
+	if len(fs) == 0 {
+		return nil
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fs {
+		f.AddTo(enc)
+	}
+	return Fields(enc.Fields)
+}
+
+func (c *zapCore) Enabled(l zapcore.Level) bool {
+	return c.el.Enabled(zapLevel(l))
+}
+
+func (c *zapCore) With(fs []zapcore.Field) zapcore.Core {
+	if fields := zapFields(fs); fields != nil {
+		return &zapCore{el: c.el.WithFields(fields)}
+	}
+	return c
+}
+
+func (c *zapCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *zapCore) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	fields := zapFields(fs)
+	if e.LoggerName != "" {
+		if fields == nil {
+			fields = Fields{}
+		}
+		fields[LoggerNameField] = e.LoggerName
+	}
+	el := c.el
+	if fields != nil {
+		el = el.WithFields(fields)
+	}
+	var pc uintptr
+	if e.Caller.Defined {
+		pc = e.Caller.PC
+	}
+	return el.emitAt(zapLevel(e.Level), e.Message, pc)
+}
+
+// Sync writes the entries queued in asynchronous mode, see Flush.
+func (c *zapCore) Sync() error {
+	c.el.Flush()
+	return nil
+}
@@ -0,0 +1,27 @@
+// +build zap
+
+package easylogger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"testing"
+)
+
+func TestEasyLogger_AsZapCore(t *testing.T) {
+	var buf bytes.Buffer
+	l := &EasyLogger{out: newSink(&buf)}
+	l.SetLevel(InfoLevel)
+	core := l.AsZapCore().With([]zapcore.Field{{Key: "service", Type: zapcore.StringType, String: "billing"}})
+
+	assert.False(t, core.Enabled(zapcore.DebugLevel))
+	assert.Nil(t, core.Check(zapcore.Entry{Level: zapcore.DebugLevel, Message: "dropped"}, nil))
+	ce := core.Check(zapcore.Entry{Level: zapcore.DPanicLevel, Message: "hello", LoggerName: "api"}, nil)
+	assert.NotNil(t, ce)
+	ce.Write(zapcore.Field{Key: "user", Type: zapcore.StringType, String: "ann"})
+	assert.Contains(t, buf.String(), ERROR)
+	assert.Contains(t, buf.String(), ", hello logger=api service=billing user=ann\n")
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Nil(t, core.Sync())
+}